@@ -0,0 +1,386 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/onosproject/onos-api/go/onos/discovery"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	podKind    = "pod"
+	rackKind   = "rack"
+	switchKind = "switch"
+	ipuKind    = "ipu"
+
+	kindColumn = "kind"
+	idColumn   = "id"
+)
+
+// csvColumns are the columns an inventory CSV file may have, named after the flags of the add commands
+var csvColumns = map[string]bool{
+	kindColumn:            true,
+	idColumn:              true,
+	podIDFlag:             true,
+	rackIDFlag:            true,
+	p4endpointFlag:        true,
+	p4rtDeviceIDFlag:      true,
+	gNMIendpointFlag:      true,
+	pipelineConfigIDFlag:  true,
+	chassisConfigIDFlag:   true,
+	linkAgentEndpointFlag: true,
+	hostAgentEndpointFlag: true,
+	natAgentEndpointFlag:  true,
+	realmFlag:             true,
+	roleFlag:              true,
+}
+
+// inventory is the declarative description of the discovery seed entities
+type inventory struct {
+	Pods     []*inventoryPod    `yaml:"pods"`
+	Racks    []*inventoryRack   `yaml:"racks"`
+	Switches []*inventoryDevice `yaml:"switches"`
+	IPUs     []*inventoryDevice `yaml:"ipus"`
+}
+
+type inventoryPod struct {
+	ID string `yaml:"id"`
+}
+
+type inventoryRack struct {
+	ID  string `yaml:"id"`
+	Pod string `yaml:"pod"`
+}
+
+type inventoryDevice struct {
+	ID                string `yaml:"id"`
+	Pod               string `yaml:"pod,omitempty"`
+	Rack              string `yaml:"rack"`
+	P4RTEndpoint      string `yaml:"p4rtEndpoint,omitempty"`
+	P4RTDeviceID      uint64 `yaml:"p4rtDeviceID,omitempty"`
+	GNMIEndpoint      string `yaml:"gnmiEndpoint,omitempty"`
+	PipelineConfigID  string `yaml:"pipelineConfig,omitempty"`
+	ChassisConfigID   string `yaml:"chassisConfig,omitempty"`
+	LinkAgentEndpoint string `yaml:"linkAgentEndpoint,omitempty"`
+	HostAgentEndpoint string `yaml:"hostAgentEndpoint,omitempty"`
+	NatAgentEndpoint  string `yaml:"natAgentEndpoint,omitempty"`
+	Realm             string `yaml:"realm,omitempty"`
+	Role              string `yaml:"role,omitempty"`
+}
+
+func getLoadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load {<inventory.yaml>|<inventory.csv>|-}",
+		Args:  cobra.ExactArgs(1),
+		Short: "Add the pods, racks, switches and IPUs of an inventory file",
+		Long: `Add the pods, racks, switches and IPUs described by an inventory file, in dependency order.
+The YAML inventory has pods, racks, switches and ipus sections; racks name their pod and switches and IPUs name their rack,
+with the pod defaulting to that of the rack. The CSV inventory has one entity per row and a header row naming its columns:
+kind (pod, rack, switch or ipu), id, and any of the flags of the add commands, e.g. pod, rack, p4rt-endpoint or realm;
+unknown keys and columns are rejected.
+All references are validated before any entity is added.`,
+		RunE: runLoadCommand,
+	}
+	cmd.Flags().String("format", "", "inventory format, yaml or csv; derived from the file extension by default")
+	return cmd
+}
+
+// inventoryFormat returns the format of the given inventory file; standard input defaults to YAML
+func inventoryFormat(path string, format string) (string, error) {
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = "csv"
+		}
+	}
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		return "yaml", nil
+	case "csv":
+		return "csv", nil
+	}
+	return "", errors.NewInvalid("unsupported inventory format %s", format)
+}
+
+func readInventory(path string, format string) (*inventory, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "csv" {
+		return parseCSVInventory(bytes.NewReader(data))
+	}
+	inv := &inventory{}
+	if err = yaml.UnmarshalStrict(data, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// parseCSVInventory reads an inventory whose columns are named by the header row
+func parseCSVInventory(r io.Reader) (*inventory, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &inventory{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !csvColumns[name] {
+			return nil, errors.NewInvalid("unknown column %s", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, errors.NewInvalid("duplicate column %s", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{kindColumn, idColumn} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.NewInvalid("missing %s column", name)
+		}
+	}
+
+	inv := &inventory{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return inv, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if err = addCSVRecord(inv, columns, record); err != nil {
+			return nil, errors.NewInvalid("line %d: %s", line, err.Error())
+		}
+	}
+}
+
+func addCSVRecord(inv *inventory, columns map[string]int, record []string) error {
+	row := make(map[string]string)
+	for name, i := range columns {
+		if i < len(record) {
+			row[name] = strings.TrimSpace(record[i])
+		}
+	}
+	switch kind := strings.ToLower(row[kindColumn]); kind {
+	case podKind:
+		inv.Pods = append(inv.Pods, &inventoryPod{ID: row[idColumn]})
+	case rackKind:
+		inv.Racks = append(inv.Racks, &inventoryRack{ID: row[idColumn], Pod: row[podIDFlag]})
+	case switchKind, ipuKind, "server", "server-ipu":
+		device := &inventoryDevice{
+			ID:                row[idColumn],
+			Pod:               row[podIDFlag],
+			Rack:              row[rackIDFlag],
+			P4RTEndpoint:      row[p4endpointFlag],
+			GNMIEndpoint:      row[gNMIendpointFlag],
+			PipelineConfigID:  row[pipelineConfigIDFlag],
+			ChassisConfigID:   row[chassisConfigIDFlag],
+			LinkAgentEndpoint: row[linkAgentEndpointFlag],
+			HostAgentEndpoint: row[hostAgentEndpointFlag],
+			NatAgentEndpoint:  row[natAgentEndpointFlag],
+			Realm:             row[realmFlag],
+			Role:              row[roleFlag],
+		}
+		if v := row[p4rtDeviceIDFlag]; v != "" {
+			deviceID, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return errors.NewInvalid("invalid %s %s", p4rtDeviceIDFlag, v)
+			}
+			device.P4RTDeviceID = deviceID
+		}
+		if kind == switchKind {
+			inv.Switches = append(inv.Switches, device)
+		} else {
+			inv.IPUs = append(inv.IPUs, device)
+		}
+	default:
+		return errors.NewInvalid("unsupported kind %s", row[kindColumn])
+	}
+	return nil
+}
+
+// validateInventory checks that all IDs are unique and that all racks, switches and IPUs
+// refer to defined parents; the pod of switches and IPUs defaults to that of their rack
+func validateInventory(inv *inventory) error {
+	ids := make(map[string]string)
+	checkID := func(kind string, id string) error {
+		if id == "" {
+			return errors.NewInvalid("%s without an ID", kind)
+		}
+		if other, ok := ids[id]; ok {
+			return errors.NewInvalid("%s %s is already defined as a %s", kind, id, other)
+		}
+		ids[id] = kind
+		return nil
+	}
+
+	pods := make(map[string]bool)
+	for _, p := range inv.Pods {
+		if err := checkID(podKind, p.ID); err != nil {
+			return err
+		}
+		pods[p.ID] = true
+	}
+
+	rackPods := make(map[string]string)
+	for _, r := range inv.Racks {
+		if err := checkID(rackKind, r.ID); err != nil {
+			return err
+		}
+		if !pods[r.Pod] {
+			return errors.NewInvalid("rack %s refers to undefined pod %s", r.ID, r.Pod)
+		}
+		rackPods[r.ID] = r.Pod
+	}
+
+	checkDevice := func(kind string, d *inventoryDevice) error {
+		if err := checkID(kind, d.ID); err != nil {
+			return err
+		}
+		pod, ok := rackPods[d.Rack]
+		if !ok {
+			return errors.NewInvalid("%s %s refers to undefined rack %s", kind, d.ID, d.Rack)
+		}
+		if d.Pod == "" {
+			d.Pod = pod
+		} else if d.Pod != pod {
+			return errors.NewInvalid("%s %s refers to pod %s but rack %s is in pod %s", kind, d.ID, d.Pod, d.Rack, pod)
+		}
+		return nil
+	}
+	for _, d := range inv.Switches {
+		if err := checkDevice(switchKind, d); err != nil {
+			return err
+		}
+	}
+	for _, d := range inv.IPUs {
+		if err := checkDevice(ipuKind, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toManagementInfo(d *inventoryDevice) *discovery.ManagementInfo {
+	return &discovery.ManagementInfo{
+		P4RTEndpoint:      d.P4RTEndpoint,
+		GNMIEndpoint:      d.GNMIEndpoint,
+		PipelineConfigID:  d.PipelineConfigID,
+		ChassisConfigID:   d.ChassisConfigID,
+		LinkAgentEndpoint: d.LinkAgentEndpoint,
+		HostAgentEndpoint: d.HostAgentEndpoint,
+		NatAgentEndpoint:  d.NatAgentEndpoint,
+		Realm:             d.Realm,
+		Role:              d.Role,
+		DeviceID:          d.P4RTDeviceID,
+	}
+}
+
+func runLoadCommand(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	format, err := inventoryFormat(args[0], format)
+	if err != nil {
+		return err
+	}
+	inv, err := readInventory(args[0], format)
+	if err != nil {
+		return err
+	}
+	if err = validateInventory(inv); err != nil {
+		return err
+	}
+
+	client, conn, err := getDiscoveryClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	pods, racks, switches, ipus := 0, 0, 0, 0
+	summary := func() {
+		cli.Output("Added %s, %s, %s and %s\n", countOf(pods, "pod", "pods"), countOf(racks, "rack", "racks"),
+			countOf(switches, "switch", "switches"), countOf(ipus, "IPU", "IPUs"))
+	}
+
+	for _, p := range inv.Pods {
+		if _, err = client.AddPod(ctx, &discovery.AddPodRequest{ID: p.ID}); err != nil {
+			summary()
+			cli.Output("Unable to add pod %s: %+v\n", p.ID, err)
+			return err
+		}
+		pods++
+	}
+	for _, r := range inv.Racks {
+		if _, err = client.AddRack(ctx, &discovery.AddRackRequest{ID: r.ID, PodID: r.Pod}); err != nil {
+			summary()
+			cli.Output("Unable to add rack %s: %+v\n", r.ID, err)
+			return err
+		}
+		racks++
+	}
+	for _, d := range inv.Switches {
+		if _, err = client.AddSwitch(ctx, &discovery.AddSwitchRequest{
+			ID:             d.ID,
+			PodID:          d.Pod,
+			RackID:         d.Rack,
+			ManagementInfo: toManagementInfo(d),
+		}); err != nil {
+			summary()
+			cli.Output("Unable to add switch %s: %+v\n", d.ID, err)
+			return err
+		}
+		switches++
+	}
+	for _, d := range inv.IPUs {
+		if _, err = client.AddServerIPU(ctx, &discovery.AddServerIPURequest{
+			ID:             d.ID,
+			PodID:          d.Pod,
+			RackID:         d.Rack,
+			ManagementInfo: toManagementInfo(d),
+		}); err != nil {
+			summary()
+			cli.Output("Unable to add IPU %s: %+v\n", d.ID, err)
+			return err
+		}
+		ipus++
+	}
+	summary()
+	return nil
+}
+
+func countOf(count int, singular string, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const testInventory = `pods:
+  - id: pod1
+racks:
+  - id: rack1
+    pod: pod1
+switches:
+  - id: leaf1
+    rack: rack1
+    p4rtEndpoint: fabric-sim:20001
+    p4rtDeviceID: 1
+    pipelineConfig: fabric-v1
+    chassisConfig: leaf-chassis
+ipus:
+  - id: ipu1-1
+    pod: pod1
+    rack: rack1
+    p4rtEndpoint: fabric-sim:20101
+`
+
+const testCSVInventory = `kind,id,pod,rack,p4rt-endpoint,p4rt-device-id,realm
+# seed entities of pod1
+pod,pod1
+rack,rack1,pod1
+switch,leaf1,,rack1,fabric-sim:20001,1,lab
+ipu,ipu1-1,pod1,rack1,fabric-sim:20101,,lab
+`
+
+func Test_ValidateInventory(t *testing.T) {
+	inv := &inventory{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testInventory), inv))
+	assert.NoError(t, validateInventory(inv))
+	assert.Equal(t, "pod1", inv.Switches[0].Pod)
+
+	mi := toManagementInfo(inv.Switches[0])
+	assert.Equal(t, "fabric-sim:20001", mi.P4RTEndpoint)
+	assert.Equal(t, uint64(1), mi.DeviceID)
+	assert.Equal(t, "fabric-v1", mi.PipelineConfigID)
+	assert.Equal(t, "leaf-chassis", mi.ChassisConfigID)
+
+	inv.Racks[0].Pod = "pod2"
+	assert.Error(t, validateInventory(inv))
+
+	inv = &inventory{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testInventory), inv))
+	inv.IPUs[0].Rack = "rack2"
+	assert.Error(t, validateInventory(inv))
+
+	inv = &inventory{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testInventory), inv))
+	inv.IPUs[0].Pod = "pod2"
+	assert.Error(t, validateInventory(inv))
+
+	inv = &inventory{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testInventory), inv))
+	inv.IPUs[0].ID = "leaf1"
+	assert.Error(t, validateInventory(inv))
+}
+
+func Test_ParseCSVInventory(t *testing.T) {
+	inv, err := parseCSVInventory(strings.NewReader(testCSVInventory))
+	assert.NoError(t, err)
+	assert.Len(t, inv.Pods, 1)
+	assert.Len(t, inv.Racks, 1)
+	assert.Len(t, inv.Switches, 1)
+	assert.Len(t, inv.IPUs, 1)
+	assert.Equal(t, "pod1", inv.Racks[0].Pod)
+	assert.Equal(t, uint64(1), inv.Switches[0].P4RTDeviceID)
+	assert.Equal(t, "lab", inv.IPUs[0].Realm)
+	assert.NoError(t, validateInventory(inv))
+
+	_, err = parseCSVInventory(strings.NewReader("kind,id\nspine,spine1\n"))
+	assert.Error(t, err)
+	_, err = parseCSVInventory(strings.NewReader("kind,pod\npod,pod1\n"))
+	assert.Error(t, err)
+	_, err = parseCSVInventory(strings.NewReader("kind,id,p4rt-device-id\nswitch,leaf1,one\n"))
+	assert.Error(t, err)
+	_, err = parseCSVInventory(strings.NewReader("kind,id,rack,p4rt_endpoint\nswitch,leaf1,rack1,fabric-sim:20001\n"))
+	assert.Error(t, err)
+}
+
+func Test_InventoryFormat(t *testing.T) {
+	format, err := inventoryFormat("inventory.CSV", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "csv", format)
+	format, err = inventoryFormat("-", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "yaml", format)
+	format, err = inventoryFormat("-", "csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "csv", format)
+	_, err = inventoryFormat("inventory.json", "json")
+	assert.Error(t, err)
+}
@@ -28,7 +28,7 @@ func Init() {
 // GetCommand returns the root command for the device provisioner service
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "discovery {add} [args]",
+		Use:     "discovery {add,load,get,remove} [args]",
 		Aliases: []string{"topo-discovery"},
 		Short:   "Topology discovery subsystem commands",
 	}
@@ -37,6 +37,9 @@ func GetCommand() *cobra.Command {
 	cmd.AddCommand(cli.GetConfigCommand())
 
 	cmd.AddCommand(getAddCommand())
+	cmd.AddCommand(getLoadCommand())
+	cmd.AddCommand(getGetCommand())
+	cmd.AddCommand(getRemoveCommand())
 	cmd.AddCommand(loglib.GetCommand())
 	return cmd
 }
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	provisionerapi "github.com/onosproject/onos-api/go/onos/provisioner"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	topoAddressFlag    = "topo-address"
+	defaultTopoAddress = "onos-topo:5150"
+)
+
+// seed is a pod, rack, switch or IPU entity created in onos-topo by the discovery service
+type seed struct {
+	id       topoapi.ID
+	kind     topoapi.ID
+	parent   *seed
+	children []*seed
+	// contained are the non-seed entities, e.g. ports, contained by the seed
+	contained []topoapi.ID
+
+	p4rtEndpoint      string
+	p4rtDeviceID      uint64
+	gnmiEndpoint      string
+	pipelineConfigID  string
+	chassisConfigID   string
+	linkAgentEndpoint string
+	hostAgentEndpoint string
+	natAgentEndpoint  string
+}
+
+// seedTree is the pod -> rack -> device tree of seed entities, along with the relations touching them
+// and the entities contained by them
+type seedTree struct {
+	seeds     map[topoapi.ID]*seed
+	roots     []*seed
+	relations map[topoapi.ID][]topoapi.ID
+}
+
+var seedKindOrder = map[topoapi.ID]int{
+	topoapi.PodKind:    0,
+	topoapi.RackKind:   1,
+	topoapi.SwitchKind: 2,
+	topoapi.IPUKind:    3,
+	topoapi.ServerKind: 3,
+}
+
+func getGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get [<id>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Get the seeded pods, racks, switches and IPUs",
+		Long: `Get the pods, racks, switches and IPUs seeded by the discovery service as a pod -> rack -> device tree,
+along with the management endpoints and the pipeline and chassis configuration IDs of the devices.
+The seeds are read from the entities the discovery service creates in onos-topo; if an ID is given,
+only the subtree of that seed is listed.`,
+		RunE: runGetCommand,
+	}
+	cmd.Flags().String(topoAddressFlag, defaultTopoAddress, "onos-topo address")
+	cmd.Flags().Bool("no-headers", false, "disables output headers")
+	cmd.Flags().BoolP("verbose", "v", false, "also show the link, host and NAT agent endpoints")
+	return cmd
+}
+
+func getRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <id>",
+		Aliases: []string{"delete"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Remove a seeded pod, rack, switch or IPU",
+		Long: `Remove a pod, rack, switch or IPU seeded by the discovery service by deleting its onos-topo entity,
+along with the entities it contains, such as the ports of switches and IPUs, and the relations to and from them.
+Seeds that contain other seeds are only removed with --recursive, in which case all the seeds below it are removed as well.`,
+		RunE: runRemoveCommand,
+	}
+	cmd.Flags().String(topoAddressFlag, defaultTopoAddress, "onos-topo address")
+	cmd.Flags().BoolP("recursive", "r", false, "also removes the seeds contained by the given seed")
+	return cmd
+}
+
+func endpoint(ep *topoapi.Endpoint) string {
+	if ep == nil || ep.Address == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", ep.Address, ep.Port)
+}
+
+func newSeed(e *topoapi.Object) *seed {
+	s := &seed{id: e.ID, kind: e.GetEntity().KindID}
+	agents := &topoapi.StratumAgents{}
+	if err := e.GetAspect(agents); err == nil {
+		s.p4rtEndpoint = endpoint(agents.P4RTEndpoint)
+		s.p4rtDeviceID = agents.DeviceID
+		s.gnmiEndpoint = endpoint(agents.GNMIEndpoint)
+	}
+	localAgents := &topoapi.LocalAgents{}
+	if err := e.GetAspect(localAgents); err == nil {
+		s.linkAgentEndpoint = endpoint(localAgents.LinkAgentEndpoint)
+		s.hostAgentEndpoint = endpoint(localAgents.HostAgentEndpoint)
+		s.natAgentEndpoint = endpoint(localAgents.NATAgentEndpoint)
+	}
+	config := &provisionerapi.DeviceConfig{}
+	if err := e.GetAspect(config); err == nil {
+		s.pipelineConfigID = string(config.PipelineConfigID)
+		s.chassisConfigID = string(config.ChassisConfigID)
+	}
+	return s
+}
+
+// newSeedTree assembles the seed entities into a tree using the contains relations between them
+func newSeedTree(objects []*topoapi.Object) *seedTree {
+	tree := &seedTree{
+		seeds:     make(map[topoapi.ID]*seed),
+		relations: make(map[topoapi.ID][]topoapi.ID),
+	}
+	for _, o := range objects {
+		if e := o.GetEntity(); e != nil {
+			if _, ok := seedKindOrder[e.KindID]; ok {
+				tree.seeds[o.ID] = newSeed(o)
+			}
+		}
+	}
+
+	// Non-seed entities contained by a seed are removed along with it, so their relations are tracked as well
+	entities := make(map[topoapi.ID]bool)
+	for _, o := range objects {
+		if o.GetEntity() != nil {
+			entities[o.ID] = true
+		}
+	}
+	tracked := make(map[topoapi.ID]bool)
+	for id := range tree.seeds {
+		tracked[id] = true
+	}
+	for _, o := range objects {
+		r := o.GetRelation()
+		if r == nil || r.KindID != topoapi.CONTAINS {
+			continue
+		}
+		if src, ok := tree.seeds[r.SrcEntityID]; ok && entities[r.TgtEntityID] && !tracked[r.TgtEntityID] {
+			tracked[r.TgtEntityID] = true
+			src.contained = append(src.contained, r.TgtEntityID)
+		}
+	}
+
+	for _, o := range objects {
+		r := o.GetRelation()
+		if r == nil {
+			continue
+		}
+		if tracked[r.SrcEntityID] {
+			tree.relations[r.SrcEntityID] = append(tree.relations[r.SrcEntityID], o.ID)
+		}
+		if tracked[r.TgtEntityID] && r.TgtEntityID != r.SrcEntityID {
+			tree.relations[r.TgtEntityID] = append(tree.relations[r.TgtEntityID], o.ID)
+		}
+		src, srcOK := tree.seeds[r.SrcEntityID]
+		tgt, tgtOK := tree.seeds[r.TgtEntityID]
+		if srcOK && tgtOK && r.KindID == topoapi.CONTAINS && tgt.parent == nil &&
+			seedKindOrder[src.kind] < seedKindOrder[tgt.kind] {
+			tgt.parent = src
+			src.children = append(src.children, tgt)
+		}
+	}
+
+	for _, s := range tree.seeds {
+		if s.parent == nil {
+			tree.roots = append(tree.roots, s)
+		}
+		sortSeeds(s.children)
+		sort.Slice(s.contained, func(i, j int) bool { return s.contained[i] < s.contained[j] })
+	}
+	sortSeeds(tree.roots)
+	return tree
+}
+
+func sortSeeds(seeds []*seed) {
+	sort.Slice(seeds, func(i, j int) bool {
+		if seeds[i].kind != seeds[j].kind {
+			return seedKindOrder[seeds[i].kind] < seedKindOrder[seeds[j].kind]
+		}
+		return seeds[i].id < seeds[j].id
+	})
+}
+
+// subtree returns the given seed and all seeds below it, parents before their children
+func (s *seed) subtree() []*seed {
+	seeds := []*seed{s}
+	for _, child := range s.children {
+		seeds = append(seeds, child.subtree()...)
+	}
+	return seeds
+}
+
+func (s *seed) depth() int {
+	depth := 0
+	for p := s.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+func runGetCommand(cmd *cobra.Command, args []string) error {
+	topoAddress, _ := cmd.Flags().GetString(topoAddressFlag)
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	objects, err := utils.QueryTopoObjects(cmd, topoAddress)
+	if err != nil {
+		cli.Output("Unable to query onos-topo: %+v\n", err)
+		return err
+	}
+	tree := newSeedTree(objects)
+
+	roots := tree.roots
+	if len(args) == 1 {
+		s, ok := tree.seeds[topoapi.ID(args[0])]
+		if !ok {
+			return errors.NewNotFound("seed %s not found", args[0])
+		}
+		roots = []*seed{s}
+	}
+
+	writer := new(tabwriter.Writer)
+	writer.Init(cli.GetOutput(), 0, 0, 3, ' ', tabwriter.FilterHTML)
+	if !noHeaders {
+		if verbose {
+			_, _ = fmt.Fprintf(writer, "ID\tKind\tP4RT Endpoint\tDevice ID\tgNMI Endpoint\tPipeline Config\tChassis Config\tLink Agent\tHost Agent\tNAT Agent\n")
+		} else {
+			_, _ = fmt.Fprintf(writer, "ID\tKind\tP4RT Endpoint\tDevice ID\tgNMI Endpoint\tPipeline Config\tChassis Config\n")
+		}
+	}
+	for _, root := range roots {
+		base := root.depth()
+		for _, s := range root.subtree() {
+			id := strings.Repeat("  ", s.depth()-base) + string(s.id)
+			deviceID := ""
+			if s.p4rtDeviceID != 0 {
+				deviceID = fmt.Sprintf("%d", s.p4rtDeviceID)
+			}
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s", id, s.kind, s.p4rtEndpoint, deviceID,
+				s.gnmiEndpoint, s.pipelineConfigID, s.chassisConfigID)
+			if verbose {
+				_, _ = fmt.Fprintf(writer, "\t%s\t%s\t%s", s.linkAgentEndpoint, s.hostAgentEndpoint, s.natAgentEndpoint)
+			}
+			_, _ = fmt.Fprintln(writer)
+		}
+	}
+	return writer.Flush()
+}
+
+// removalPlan returns the relations and then the entities to delete to remove the given seed, children first;
+// the entities contained by each seed, e.g. ports, are deleted right before it
+func (t *seedTree) removalPlan(id topoapi.ID, recursive bool) ([]topoapi.ID, []topoapi.ID, error) {
+	s, ok := t.seeds[id]
+	if !ok {
+		return nil, nil, errors.NewNotFound("seed %s not found", id)
+	}
+	if len(s.children) > 0 && !recursive {
+		return nil, nil, errors.NewInvalid("%s %s contains %d seed(s); use --recursive to remove them as well",
+			s.kind, s.id, len(s.children))
+	}
+
+	seeds := s.subtree()
+	relations := make([]topoapi.ID, 0)
+	seen := make(map[topoapi.ID]bool)
+	entities := make([]topoapi.ID, 0, len(seeds))
+	for i := len(seeds) - 1; i >= 0; i-- {
+		for _, id := range append(append([]topoapi.ID{}, seeds[i].contained...), seeds[i].id) {
+			for _, r := range t.relations[id] {
+				if !seen[r] {
+					seen[r] = true
+					relations = append(relations, r)
+				}
+			}
+			entities = append(entities, id)
+		}
+	}
+	return relations, entities, nil
+}
+
+func runRemoveCommand(cmd *cobra.Command, args []string) error {
+	topoAddress, _ := cmd.Flags().GetString(topoAddressFlag)
+	recursive, _ := cmd.Flags().GetBool("recursive")
+
+	objects, err := utils.QueryTopoObjects(cmd, topoAddress)
+	if err != nil {
+		cli.Output("Unable to query onos-topo: %+v\n", err)
+		return err
+	}
+	relations, entities, err := newSeedTree(objects).removalPlan(topoapi.ID(args[0]), recursive)
+	if err != nil {
+		return err
+	}
+
+	conn, err := utils.GetServiceConnection(cmd, topoAddress)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := topoapi.CreateTopoClient(conn)
+	ctx := context.Background()
+
+	// Relations may already be gone along with an entity deleted before them
+	for _, id := range append(relations, entities...) {
+		if _, err = client.Delete(ctx, &topoapi.DeleteRequest{ID: id}); err != nil && !errors.IsNotFound(errors.FromGRPC(err)) {
+			cli.Output("Unable to remove %s: %+v\n", id, err)
+			return err
+		}
+	}
+	cli.Output("Removed %s\n", countOf(len(entities), "entity", "entities"))
+	return nil
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	provisionerapi "github.com/onosproject/onos-api/go/onos/provisioner"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/stretchr/testify/assert"
+)
+
+func withAspect(t *testing.T, object *topoapi.Object, aspect proto.Message) *topoapi.Object {
+	object, err := object.WithAspects(aspect)
+	assert.NoError(t, err)
+	return object
+}
+
+func testSeedObjects(t *testing.T) []*topoapi.Object {
+	leaf1 := withAspect(t, topoapi.NewEntity("leaf1", topoapi.SwitchKind), &topoapi.StratumAgents{
+		P4RTEndpoint: &topoapi.Endpoint{Address: "fabric-sim", Port: 20001},
+		GNMIEndpoint: &topoapi.Endpoint{Address: "fabric-sim", Port: 20001},
+		DeviceID:     1,
+	})
+	leaf1 = withAspect(t, leaf1, &provisionerapi.DeviceConfig{PipelineConfigID: "fabric-v1", ChassisConfigID: "leaf-chassis"})
+	ipu := withAspect(t, topoapi.NewEntity("ipu1-1", topoapi.IPUKind), &topoapi.LocalAgents{
+		LinkAgentEndpoint: &topoapi.Endpoint{Address: "ipu1-1", Port: 30000},
+	})
+
+	return []*topoapi.Object{
+		topoapi.NewEntity("pod1", topoapi.PodKind),
+		topoapi.NewEntity("rack2", topoapi.RackKind),
+		topoapi.NewEntity("rack1", topoapi.RackKind),
+		leaf1,
+		ipu,
+		topoapi.NewEntity("leaf1/1", topoapi.PortKind),
+		topoapi.NewEntity("spine9", topoapi.SwitchKind),
+		topoapi.NewRelation("pod1", "rack1", topoapi.CONTAINS),
+		topoapi.NewRelation("pod1", "rack2", topoapi.CONTAINS),
+		topoapi.NewRelation("rack1", "ipu1-1", topoapi.CONTAINS),
+		topoapi.NewRelation("rack1", "leaf1", topoapi.CONTAINS),
+		topoapi.NewRelation("leaf1", "leaf1/1", topoapi.CONTAINS),
+	}
+}
+
+func seedIDs(seeds []*seed) []topoapi.ID {
+	ids := make([]topoapi.ID, 0, len(seeds))
+	for _, s := range seeds {
+		ids = append(ids, s.id)
+	}
+	return ids
+}
+
+func Test_SeedTree(t *testing.T) {
+	tree := newSeedTree(testSeedObjects(t))
+	assert.Len(t, tree.seeds, 6)
+	assert.Equal(t, []topoapi.ID{"pod1", "spine9"}, seedIDs(tree.roots))
+	assert.Equal(t, []topoapi.ID{"pod1", "rack1", "leaf1", "ipu1-1", "rack2"}, seedIDs(tree.roots[0].subtree()))
+	assert.Equal(t, 2, tree.seeds["ipu1-1"].depth())
+
+	leaf1 := tree.seeds["leaf1"]
+	assert.Equal(t, "fabric-sim:20001", leaf1.p4rtEndpoint)
+	assert.Equal(t, "fabric-sim:20001", leaf1.gnmiEndpoint)
+	assert.Equal(t, uint64(1), leaf1.p4rtDeviceID)
+	assert.Equal(t, "fabric-v1", leaf1.pipelineConfigID)
+	assert.Equal(t, "leaf-chassis", leaf1.chassisConfigID)
+	assert.Equal(t, "ipu1-1:30000", tree.seeds["ipu1-1"].linkAgentEndpoint)
+	assert.Equal(t, "", tree.seeds["ipu1-1"].p4rtEndpoint)
+}
+
+func Test_RemovalPlan(t *testing.T) {
+	tree := newSeedTree(testSeedObjects(t))
+
+	_, _, err := tree.removalPlan("rack1", false)
+	assert.Error(t, err)
+	_, _, err = tree.removalPlan("rack9", true)
+	assert.Error(t, err)
+
+	relations, entities, err := tree.removalPlan("leaf1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []topoapi.ID{"leaf1/1", "leaf1"}, entities)
+	assert.Len(t, relations, 2)
+
+	relations, entities, err = tree.removalPlan("rack1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []topoapi.ID{"ipu1-1", "leaf1/1", "leaf1", "rack1"}, entities)
+	assert.Len(t, relations, 4)
+}
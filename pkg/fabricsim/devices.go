@@ -34,7 +34,9 @@ func getDevicesCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "devices",
 		Short: "Get all simulated devices",
-		RunE:  runGetDevicesCommand,
+		Long: `Get all simulated devices. With --watch or --top, the I/O stats of the devices are polled every --interval
+and their rates are shown instead, as by the stats command.`,
+		RunE: runGetDevicesCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
 	cmd.Flags().Bool("ports", false, "enables listing of ports")
@@ -42,6 +44,7 @@ func getDevicesCommand() *cobra.Command {
 	cmd.Flags().Bool("no-empty-info", false, "disables listing of entities with size 0")
 	cmd.Flags().Bool("connections", false, "enables listing of current connections")
 	cmd.Flags().Bool("stats", false, "enables listing of I/O stats")
+	addStatsWatchFlags(cmd)
 	return cmd
 }
 
@@ -118,9 +121,13 @@ func getStatsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "Get cumulative I/O stats",
-		RunE:  runGetStatsCommand,
+		Long: `Get cumulative I/O stats. With --watch or --top, the I/O stats of the devices are polled every --interval and
+the messages and bytes per second of each device and of all devices together are shown, along with the
+number of messages received and sent since the previous poll.`,
+		RunE: runGetStatsCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
+	addStatsWatchFlags(cmd)
 	return cmd
 }
 
@@ -140,6 +147,22 @@ func getFabricSimClient(cmd *cobra.Command) (simapi.FabricSimulatorClient, *grpc
 	return simapi.NewFabricSimulatorClient(conn), conn, nil
 }
 
+// devicePorts returns the given number of ports of a device, numbered from 1
+func devicePorts(id simapi.DeviceID, portCount uint16) []*simapi.Port {
+	// FIXME: This is just a quick hack to allow creating device ports en masse; implement proper creation later
+	ports := make([]*simapi.Port, 0, portCount)
+	for pn := uint16(1); pn <= portCount; pn++ {
+		ports = append(ports, &simapi.Port{
+			ID:             simapi.PortID(fmt.Sprintf("%s/%d", id, pn)),
+			Name:           fmt.Sprintf("%d", pn),
+			Number:         uint32(pn),
+			InternalNumber: uint32(1024 + pn),
+			Speed:          "100Gbps",
+		})
+	}
+	return ports
+}
+
 func runCreateDeviceCommand(cmd *cobra.Command, args []string) error {
 	client, conn, err := getDeviceClient(cmd)
 	if err != nil {
@@ -156,23 +179,12 @@ func runCreateDeviceCommand(cmd *cobra.Command, args []string) error {
 
 	agentPort, _ := cmd.Flags().GetUint16("agent-port")
 
-	// FIXME: This is just a quick hack to allow creating device ports en masse; implement proper creation later
 	portCount, _ := cmd.Flags().GetUint16("port-count")
-	ports := make([]*simapi.Port, 0, portCount)
-	for pn := uint16(1); pn <= portCount; pn++ {
-		ports = append(ports, &simapi.Port{
-			ID:             simapi.PortID(fmt.Sprintf("%s/%d", id, pn)),
-			Name:           fmt.Sprintf("%d", pn),
-			Number:         uint32(pn),
-			InternalNumber: uint32(1024 + pn),
-			Speed:          "100Gbps",
-		})
-	}
 
 	device := &simapi.Device{
 		ID:          id,
 		Type:        deviceType,
-		Ports:       ports,
+		Ports:       devicePorts(id, portCount),
 		ControlPort: int32(agentPort),
 	}
 
@@ -192,6 +204,10 @@ func runCreateDeviceCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runGetDevicesCommand(cmd *cobra.Command, _ []string) error {
+	if watchStats(cmd) {
+		return runWatchStats(cmd)
+	}
+
 	client, conn, err := getDeviceClient(cmd)
 	if err != nil {
 		return err
@@ -444,7 +460,18 @@ func printEntitiesInfo(kind string, infos []*simapi.EntitiesInfo, noEmptyInfo bo
 	}
 }
 
+// watchStats returns true if the command is to poll the device stats and show their rates
+func watchStats(cmd *cobra.Command) bool {
+	watch, _ := cmd.Flags().GetBool("watch")
+	top, _ := cmd.Flags().GetInt("top")
+	return watch || top != 0
+}
+
 func runGetStatsCommand(cmd *cobra.Command, _ []string) error {
+	if watchStats(cmd) {
+		return runWatchStats(cmd)
+	}
+
 	client, conn, err := getFabricSimClient(cmd)
 	if err != nil {
 		return err
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"context"
+	"fmt"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"net"
+	"time"
+)
+
+func emitLLDPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lldp <port-id>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Emit LLDP packet(s) as received on specified device port",
+		Long: `Emit LLDP packets as received on a device port. By default, the packets advertise the device and port
+number at the other end of the link whose target is the port; use --chassis-id and --port-number to
+advertise another neighbor.`,
+		RunE: runEmitLLDPCommand,
+	}
+	cmd.Flags().Int("count", 1, "number of packets to emit")
+	cmd.Flags().Float64("rate", 10, "packets per second; 0 emits as fast as possible")
+	cmd.Flags().String("chassis-id", "", "chassis ID of the advertised neighbor")
+	cmd.Flags().String("port-number", "", "port number of the advertised neighbor")
+	cmd.Flags().String("src-mac", "02:00:00:00:00:01", "source MAC address of the packets")
+	cmd.Flags().Uint16("ttl", 120, "time to live of the advertisement in seconds")
+	return cmd
+}
+
+// emitLLDPPackets emits the packet on the port --count times, at the --rate given in packets per second
+func emitLLDPPackets(cmd *cobra.Command, conn *grpc.ClientConn, portID simapi.PortID, packet []byte) error {
+	count, _ := cmd.Flags().GetInt("count")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	if count < 1 {
+		return errors.NewInvalid("--count must be positive")
+	}
+	if rate < 0 {
+		return errors.NewInvalid("--rate must not be negative")
+	}
+
+	client := simapi.NewDeviceServiceClient(conn)
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		if rate > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(float64(i) / rate * float64(time.Second)))))
+		}
+		if _, err := client.EmitLLDPPacket(context.Background(), &simapi.EmitLLDPPacketRequest{PortID: portID, Packet: packet}); err != nil {
+			cli.Output("Unable to emit LLDP packet %d: %+v\n", i+1, err)
+			return err
+		}
+	}
+	cli.Output("Emitted %d LLDP packet(s) on port %s in %s\n", count, portID, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// portOwner returns the ID of the device with the given port and the port number
+func portOwner(conn *grpc.ClientConn, portID simapi.PortID) (simapi.DeviceID, uint32, error) {
+	resp, err := simapi.NewDeviceServiceClient(conn).GetDevices(context.Background(), &simapi.GetDevicesRequest{})
+	if err != nil {
+		return "", 0, err
+	}
+	for _, d := range resp.Devices {
+		for _, p := range d.Ports {
+			if p.ID == portID {
+				return d.ID, p.Number, nil
+			}
+		}
+	}
+	return "", 0, errors.NewNotFound("port %s not found", portID)
+}
+
+func runEmitLLDPCommand(cmd *cobra.Command, args []string) error {
+	portID := simapi.PortID(args[0])
+	chassisID, _ := cmd.Flags().GetString("chassis-id")
+	neighborPort, _ := cmd.Flags().GetString("port-number")
+	ttl, _ := cmd.Flags().GetUint16("ttl")
+	srcMAC, _ := cmd.Flags().GetString("src-mac")
+	mac, err := net.ParseMAC(srcMAC)
+	if err != nil {
+		return errors.NewInvalid("invalid source MAC address %s", srcMAC)
+	}
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if chassisID == "" || neighborPort == "" {
+		resp, err := simapi.NewLinkServiceClient(conn).GetLinks(context.Background(), &simapi.GetLinksRequest{})
+		if err != nil {
+			return err
+		}
+		var peer simapi.PortID
+		for _, l := range resp.Links {
+			if l.TgtID == portID {
+				peer = l.SrcID
+			}
+		}
+		if peer == "" {
+			return errors.NewNotFound("no link to port %s; use --chassis-id and --port-number", portID)
+		}
+		deviceID, number, err := portOwner(conn, peer)
+		if err != nil {
+			return err
+		}
+		if chassisID == "" {
+			chassisID = string(deviceID)
+		}
+		if neighborPort == "" {
+			neighborPort = fmt.Sprint(number)
+		}
+	}
+
+	return emitLLDPPackets(cmd, conn, portID, lldpFrame(mac, chassisID, neighborPort, ttl))
+}
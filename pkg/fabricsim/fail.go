@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"context"
+	"fmt"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const failLong = `A link is taken down by disabling the ports at both of its ends, which takes its inverse link down
+as well, and restored by enabling them again.`
+
+func failLinkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link <id>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Fail a simulated link",
+		Long: `Fail a simulated link, either until its ports are enabled again or, with --duration, for the given time.
+` + failLong,
+		RunE: runFailLinkCommand,
+	}
+	cmd.Flags().Duration("duration", 0, "time after which the link is restored; 0 leaves it down")
+	cmd.Flags().Bool("chaotic", false, "use chaotic stop mode")
+	return cmd
+}
+
+func flapCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flap --links <selector>",
+		Args:  cobra.NoArgs,
+		Short: "Repeatedly fail and restore simulated links",
+		Long: `Repeatedly fail and restore the selected links: at the start of every period the links are failed, and
+after --down-time they are restored. The selector is a comma-separated list of patterns matched against
+the link IDs and their source and target port IDs, where * matches any text, e.g. 'leaf1/*,spine2/3'.
+The links are restored when flapping is interrupted.
+` + failLong,
+		RunE: runFlapCommand,
+	}
+	cmd.Flags().String("links", "", "selector of the links to flap")
+	cmd.Flags().Duration("period", 30*time.Second, "period of the flaps")
+	cmd.Flags().Duration("down-time", 0, "time the links stay down in each period; defaults to half the period")
+	cmd.Flags().Int("count", 0, "number of flaps; 0 flaps until interrupted")
+	cmd.Flags().Bool("chaotic", false, "use chaotic stop mode")
+	cmd.Flags().String("timeline", "", "file to which the timeline is written")
+	_ = cmd.MarkFlagRequired("links")
+	return cmd
+}
+
+// linkSelector returns a function matching links against a comma-separated list of patterns
+func linkSelector(selector string) (func(link *simapi.Link) bool, error) {
+	patterns := make([]*regexp.Regexp, 0)
+	for _, p := range strings.Split(selector, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		re, err := regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, ".*") + "$")
+		if err != nil {
+			return nil, errors.NewInvalid("invalid link selector %s", p)
+		}
+		patterns = append(patterns, re)
+	}
+	if len(patterns) == 0 {
+		return nil, errors.NewInvalid("empty link selector")
+	}
+	return func(link *simapi.Link) bool {
+		for _, re := range patterns {
+			if re.MatchString(string(link.ID)) || re.MatchString(string(link.SrcID)) || re.MatchString(string(link.TgtID)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// linkPorts returns the sorted, distinct ports at the ends of the links
+func linkPorts(links []*simapi.Link) []simapi.PortID {
+	seen := make(map[simapi.PortID]bool)
+	ports := make([]simapi.PortID, 0, 2*len(links))
+	for _, l := range links {
+		for _, p := range []simapi.PortID{l.SrcID, l.TgtID} {
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// linkIDs returns the IDs of the links, followed by their inverse links
+func linkIDs(links []*simapi.Link, all []*simapi.Link) []string {
+	selected := make(map[simapi.LinkID]bool)
+	for _, l := range links {
+		selected[l.ID] = true
+	}
+	ids := make([]string, 0, len(links))
+	for _, l := range links {
+		ids = append(ids, string(l.ID))
+	}
+	for _, l := range all {
+		if !selected[l.ID] {
+			for _, s := range links {
+				if l.SrcID == s.TgtID && l.TgtID == s.SrcID {
+					selected[l.ID] = true
+					ids = append(ids, string(l.ID))
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// linkToggler disables and enables the ports of links, logging each change to a timeline
+type linkToggler struct {
+	client   simapi.DeviceServiceClient
+	mode     simapi.StopMode
+	timeline io.Writer
+}
+
+func (t *linkToggler) log(format string, args ...interface{}) error {
+	line := fmt.Sprintf("%s %s", time.Now().Format("15:04:05.000"), fmt.Sprintf(format, args...))
+	cli.Output("%s\n", line)
+	_, err := fmt.Fprintln(t.timeline, line)
+	return err
+}
+
+// fail disables the ports; if any of them cannot be disabled, the ones already disabled are enabled again
+func (t *linkToggler) fail(ids []string, ports []simapi.PortID) error {
+	for i, p := range ports {
+		if _, err := t.client.DisablePort(context.Background(), &simapi.DisablePortRequest{ID: p, Mode: t.mode}); err != nil {
+			cli.Output("Unable to disable port %s: %+v\n", p, err)
+			_ = t.enablePorts(ports[:i])
+			return err
+		}
+	}
+	return t.log("down %s", strings.Join(ids, " "))
+}
+
+func (t *linkToggler) restore(ids []string, ports []simapi.PortID) error {
+	if err := t.enablePorts(ports); err != nil {
+		return err
+	}
+	return t.log("up %s", strings.Join(ids, " "))
+}
+
+// enablePorts enables all the ports, even if some of them cannot be enabled, and returns the first error
+func (t *linkToggler) enablePorts(ports []simapi.PortID) error {
+	var firstErr error
+	for _, p := range ports {
+		if _, err := t.client.EnablePort(context.Background(), &simapi.EnablePortRequest{ID: p}); err != nil {
+			cli.Output("Unable to enable port %s: %+v\n", p, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func getLinkToggler(cmd *cobra.Command, client simapi.DeviceServiceClient, timeline io.Writer) *linkToggler {
+	mode := simapi.StopMode_ORDERLY_STOP
+	if chaotic, _ := cmd.Flags().GetBool("chaotic"); chaotic {
+		mode = simapi.StopMode_CHAOTIC_STOP
+	}
+	return &linkToggler{client: client, mode: mode, timeline: timeline}
+}
+
+func runFailLinkCommand(cmd *cobra.Command, args []string) error {
+	duration, _ := cmd.Flags().GetDuration("duration")
+	if duration < 0 {
+		return errors.NewInvalid("--duration must not be negative")
+	}
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := simapi.NewLinkServiceClient(conn).GetLinks(context.Background(), &simapi.GetLinksRequest{})
+	if err != nil {
+		return err
+	}
+	var link *simapi.Link
+	for _, l := range resp.Links {
+		if l.ID == simapi.LinkID(args[0]) {
+			link = l
+		}
+	}
+	if link == nil {
+		return errors.NewNotFound("link %s not found", args[0])
+	}
+
+	links := []*simapi.Link{link}
+	ids, ports := linkIDs(links, resp.Links), linkPorts(links)
+	toggler := getLinkToggler(cmd, simapi.NewDeviceServiceClient(conn), io.Discard)
+	if err = toggler.fail(ids, ports); err != nil || duration == 0 {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	select {
+	case <-time.After(duration):
+	case <-interrupt:
+	}
+	return toggler.restore(ids, ports)
+}
+
+func runFlapCommand(cmd *cobra.Command, _ []string) error {
+	selector, _ := cmd.Flags().GetString("links")
+	period, _ := cmd.Flags().GetDuration("period")
+	downTime, _ := cmd.Flags().GetDuration("down-time")
+	count, _ := cmd.Flags().GetInt("count")
+	timelinePath, _ := cmd.Flags().GetString("timeline")
+	if !cmd.Flags().Changed("down-time") {
+		downTime = period / 2
+	}
+	if period <= 0 || downTime <= 0 || downTime >= period {
+		return errors.NewInvalid("--period must be positive and --down-time must be positive and shorter than --period")
+	}
+	if count < 0 {
+		return errors.NewInvalid("--count must not be negative")
+	}
+	matches, err := linkSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := simapi.NewLinkServiceClient(conn).GetLinks(context.Background(), &simapi.GetLinksRequest{})
+	if err != nil {
+		return err
+	}
+	links := make([]*simapi.Link, 0)
+	for _, l := range resp.Links {
+		if matches(l) {
+			links = append(links, l)
+		}
+	}
+	if len(links) == 0 {
+		return errors.NewNotFound("no links match %s", selector)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	ids, ports := linkIDs(links, resp.Links), linkPorts(links)
+
+	var timeline io.Writer = io.Discard
+	if timelinePath != "" {
+		f, err := os.Create(timelinePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		timeline = f
+	}
+	toggler := getLinkToggler(cmd, simapi.NewDeviceServiceClient(conn), timeline)
+	if err = toggler.log("flap %d link(s) on %d port(s) period %s down-time %s", len(ids), len(ports), period, downTime); err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	start := time.Now()
+	for i := 0; count == 0 || i < count; i++ {
+		select {
+		case <-time.After(time.Until(start.Add(time.Duration(i) * period))):
+		case <-interrupt:
+			return errors.NewCanceled("flapping interrupted")
+		}
+		if err = toggler.fail(ids, ports); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(time.Until(start.Add(time.Duration(i)*period + downTime))):
+		case <-interrupt:
+			if err = toggler.restore(ids, ports); err != nil {
+				return err
+			}
+			return errors.NewCanceled("flapping interrupted")
+		}
+		if err = toggler.restore(ids, ports); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func testLink(src simapi.PortID, tgt simapi.PortID) *simapi.Link {
+	return &simapi.Link{ID: simapi.NewLinkID(src, tgt), SrcID: src, TgtID: tgt}
+}
+
+func Test_LinkSelector(t *testing.T) {
+	matches, err := linkSelector("leaf1/*, spine2/3")
+	assert.NoError(t, err)
+	assert.True(t, matches(testLink("leaf1/1", "spine1/1")))
+	assert.True(t, matches(testLink("spine1/1", "leaf1/1")))
+	assert.True(t, matches(testLink("leaf3/2", "spine2/3")))
+	assert.False(t, matches(testLink("leaf10/1", "spine1/10")))
+	assert.False(t, matches(testLink("leaf2/2", "spine2/2")))
+
+	matches, err = linkSelector("leaf1/1-spine1/1")
+	assert.NoError(t, err)
+	assert.True(t, matches(testLink("leaf1/1", "spine1/1")))
+	assert.False(t, matches(testLink("spine1/1", "leaf1/1")))
+
+	_, err = linkSelector(" , ")
+	assert.Error(t, err)
+}
+
+func Test_LinkPortsAndIDs(t *testing.T) {
+	all := []*simapi.Link{
+		testLink("leaf1/1", "spine1/1"),
+		testLink("spine1/1", "leaf1/1"),
+		testLink("leaf1/2", "spine2/1"),
+		testLink("spine2/1", "leaf1/2"),
+	}
+	selected := []*simapi.Link{all[0], all[1], all[2]}
+	assert.Equal(t, []simapi.PortID{"leaf1/1", "leaf1/2", "spine1/1", "spine2/1"}, linkPorts(selected))
+	assert.Equal(t, []string{"leaf1/1-spine1/1", "spine1/1-leaf1/1", "leaf1/2-spine2/1", "spine2/1-leaf1/2"},
+		linkIDs(selected, all))
+}
+
+// testDeviceClient tracks the disabled ports, failing to disable the given port
+type testDeviceClient struct {
+	simapi.DeviceServiceClient
+	disabled map[simapi.PortID]bool
+	failing  simapi.PortID
+}
+
+func (c *testDeviceClient) DisablePort(_ context.Context, in *simapi.DisablePortRequest, _ ...grpc.CallOption) (*simapi.DisablePortResponse, error) {
+	if in.ID == c.failing {
+		return nil, errors.NewUnavailable("port %s unavailable", in.ID)
+	}
+	c.disabled[in.ID] = true
+	return &simapi.DisablePortResponse{}, nil
+}
+
+func (c *testDeviceClient) EnablePort(_ context.Context, in *simapi.EnablePortRequest, _ ...grpc.CallOption) (*simapi.EnablePortResponse, error) {
+	delete(c.disabled, in.ID)
+	return &simapi.EnablePortResponse{}, nil
+}
+
+func Test_LinkTogglerFail(t *testing.T) {
+	client := &testDeviceClient{disabled: make(map[simapi.PortID]bool), failing: "spine1/1"}
+	toggler := &linkToggler{client: client, timeline: io.Discard}
+	ports := []simapi.PortID{"leaf1/1", "leaf2/1", "spine1/1", "spine1/2"}
+
+	assert.Error(t, toggler.fail([]string{"leaf1/1-spine1/1"}, ports))
+	assert.Empty(t, client.disabled)
+
+	client.failing = ""
+	assert.NoError(t, toggler.fail([]string{"leaf1/1-spine1/1"}, ports))
+	assert.Len(t, client.disabled, 4)
+	assert.NoError(t, toggler.restore([]string{"leaf1/1-spine1/1"}, ports))
+	assert.Empty(t, client.disabled)
+}
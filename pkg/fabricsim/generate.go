@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+)
+
+// leafSpineSpec holds the parameters of a generated leaf-spine fabric
+type leafSpineSpec struct {
+	spines         int
+	leaves         int
+	hostsPerLeaf   int
+	ipus           bool
+	portCount      uint16
+	firstAgentPort uint16
+}
+
+// seedSpec holds the parameters of a generated discovery seed
+type seedSpec struct {
+	pod            string
+	agentHost      string
+	pipelineConfig string
+	chassisConfig  string
+}
+
+func getGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate {leaf-spine} [args]",
+		Short: "Commands for generating simulated fabric topologies",
+	}
+
+	cmd.AddCommand(generateLeafSpineCommand())
+	return cmd
+}
+
+func generateLeafSpineCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leaf-spine [<topology.yaml>|-]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Generate a leaf-spine fabric as a topology YAML file",
+		Long: `Generate a leaf-spine fabric as a topology YAML file that can be given to the load command.
+Leaf port N connects to spine N, and spine port N to leaf N; the hosts of a leaf use the ports that
+follow the spine uplinks. With --ipus, each host is attached through an IPU of its own, on IPU port 2,
+with IPU port 1 connected to the leaf. Host N of leaf L has the MAC address 0a:00:00:00:LL:NN and the
+IP address 10.10.L.N. Agent ports are allocated in order to spines, leaves and IPUs.
+
+With --discovery-seed, a script of 'onos discovery add' commands that seeds topology discovery with
+the generated switches and IPUs is written as well; leaf L and its IPUs are placed in rack L, and the
+spines in the rack named spines.`,
+		RunE: runGenerateLeafSpineCommand,
+	}
+	cmd.Flags().Int("spines", 2, "number of spine switches")
+	cmd.Flags().Int("leaves", 4, "number of leaf switches")
+	cmd.Flags().Int("hosts-per-leaf", 8, "number of hosts attached to each leaf")
+	cmd.Flags().Bool("ipus", false, "attach each host through an IPU")
+	cmd.Flags().Uint16("port-count", 32, "number of ports of each switch")
+	cmd.Flags().Uint16("first-agent-port", 20001, "agent gRPC (TCP) port of the first device")
+	cmd.Flags().String("discovery-seed", "", "file to which the discovery seed script is written")
+	cmd.Flags().String("pod", "pod1", "pod ID used in the discovery seed")
+	cmd.Flags().String("agent-host", "fabric-sim", "host name of the device agents used in the discovery seed")
+	cmd.Flags().String("pipeline-config", "", "pipeline configuration ID used in the discovery seed")
+	cmd.Flags().String("chassis-config", "", "chassis configuration ID used in the discovery seed")
+	return cmd
+}
+
+// generateLeafSpine builds the topology of a leaf-spine fabric
+func generateLeafSpine(spec *leafSpineSpec) (*topology, error) {
+	if spec.spines < 1 || spec.leaves < 1 || spec.hostsPerLeaf < 0 {
+		return nil, errors.NewInvalid("at least one spine and one leaf are required")
+	}
+	if spec.leaves > 254 || spec.hostsPerLeaf > 254 {
+		return nil, errors.NewInvalid("at most 254 leaves and 254 hosts per leaf are supported")
+	}
+	if int(spec.portCount) < spec.leaves {
+		return nil, errors.NewInvalid("spines need %d ports for the leaves; only %d available", spec.leaves, spec.portCount)
+	}
+	if int(spec.portCount) < spec.spines+spec.hostsPerLeaf {
+		return nil, errors.NewInvalid("leaves need %d ports for the spines and hosts; only %d available",
+			spec.spines+spec.hostsPerLeaf, spec.portCount)
+	}
+	devices := spec.spines + spec.leaves
+	if spec.ipus {
+		devices += spec.leaves * spec.hostsPerLeaf
+	}
+	if int(spec.firstAgentPort)+devices-1 > 65535 {
+		return nil, errors.NewInvalid("not enough agent ports above %d for %d devices", spec.firstAgentPort, devices)
+	}
+
+	topo := &topology{}
+	agentPort := spec.firstAgentPort
+	addDevice := func(id string, deviceType string, portCount uint16) {
+		topo.Devices = append(topo.Devices, &topoDevice{ID: id, Type: deviceType, AgentPort: agentPort, PortCount: portCount})
+		agentPort++
+	}
+
+	for s := 1; s <= spec.spines; s++ {
+		addDevice(spineID(s), "switch", spec.portCount)
+	}
+	for l := 1; l <= spec.leaves; l++ {
+		addDevice(leafID(l), "switch", spec.portCount)
+		for s := 1; s <= spec.spines; s++ {
+			topo.Links = append(topo.Links, &topoLink{
+				Src:           fmt.Sprintf("%s/%d", leafID(l), s),
+				Tgt:           fmt.Sprintf("%s/%d", spineID(s), l),
+				Bidirectional: true,
+			})
+		}
+	}
+
+	for l := 1; l <= spec.leaves; l++ {
+		for h := 1; h <= spec.hostsPerLeaf; h++ {
+			port := fmt.Sprintf("%s/%d", leafID(l), spec.spines+h)
+			if spec.ipus {
+				ipu := ipuID(l, h)
+				addDevice(ipu, "IPU", 2)
+				topo.Links = append(topo.Links, &topoLink{Src: port, Tgt: ipu + "/1", Bidirectional: true})
+				port = ipu + "/2"
+			}
+			topo.Hosts = append(topo.Hosts, &topoHost{
+				ID: fmt.Sprintf("host%d-%d", l, h),
+				NICs: []*topoNIC{{
+					Port: port,
+					MAC:  fmt.Sprintf("0a:00:00:00:%02x:%02x", l, h),
+					IP:   fmt.Sprintf("10.10.%d.%d", l, h),
+				}},
+			})
+		}
+	}
+	return topo, nil
+}
+
+func spineID(s int) string {
+	return fmt.Sprintf("spine%d", s)
+}
+
+func leafID(l int) string {
+	return fmt.Sprintf("leaf%d", l)
+}
+
+func ipuID(l int, h int) string {
+	return fmt.Sprintf("ipu%d-%d", l, h)
+}
+
+// discoverySeed renders the 'onos discovery add' commands that seed topology discovery with the
+// switches and IPUs of a generated leaf-spine fabric
+func discoverySeed(spec *leafSpineSpec, seed *seedSpec, topo *topology) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "#!/bin/sh\n# Discovery seed of a fabric with %d spines, %d leaves and %d hosts per leaf\n",
+		spec.spines, spec.leaves, spec.hostsPerLeaf)
+	fmt.Fprintf(buf, "onos discovery add pod %s\n", seed.pod)
+	fmt.Fprintf(buf, "onos discovery add rack spines --pod %s\n", seed.pod)
+	for l := 1; l <= spec.leaves; l++ {
+		fmt.Fprintf(buf, "onos discovery add rack rack%d --pod %s\n", l, seed.pod)
+	}
+
+	racks := make(map[string]string)
+	for s := 1; s <= spec.spines; s++ {
+		racks[spineID(s)] = "spines"
+	}
+	for l := 1; l <= spec.leaves; l++ {
+		racks[leafID(l)] = fmt.Sprintf("rack%d", l)
+		for h := 1; h <= spec.hostsPerLeaf; h++ {
+			racks[ipuID(l, h)] = fmt.Sprintf("rack%d", l)
+		}
+	}
+
+	for _, d := range topo.Devices {
+		kind := "switch"
+		if d.Type == "IPU" {
+			kind = "ipu"
+		}
+		endpoint := fmt.Sprintf("%s:%d", seed.agentHost, d.AgentPort)
+		fmt.Fprintf(buf, "onos discovery add %s %s --pod %s --rack %s --p4rt-endpoint %s --gnmi-endpoint %s",
+			kind, d.ID, seed.pod, racks[d.ID], endpoint, endpoint)
+		if seed.pipelineConfig != "" {
+			fmt.Fprintf(buf, " --pipeline-config %s", seed.pipelineConfig)
+		}
+		if seed.chassisConfig != "" {
+			fmt.Fprintf(buf, " --chassis-config %s", seed.chassisConfig)
+		}
+		fmt.Fprintln(buf)
+	}
+	return buf.Bytes()
+}
+
+func runGenerateLeafSpineCommand(cmd *cobra.Command, args []string) error {
+	spec := &leafSpineSpec{}
+	spec.spines, _ = cmd.Flags().GetInt("spines")
+	spec.leaves, _ = cmd.Flags().GetInt("leaves")
+	spec.hostsPerLeaf, _ = cmd.Flags().GetInt("hosts-per-leaf")
+	spec.ipus, _ = cmd.Flags().GetBool("ipus")
+	spec.portCount, _ = cmd.Flags().GetUint16("port-count")
+	spec.firstAgentPort, _ = cmd.Flags().GetUint16("first-agent-port")
+
+	topo, err := generateLeafSpine(spec)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(topo)
+	if err != nil {
+		return err
+	}
+
+	if seedPath, _ := cmd.Flags().GetString("discovery-seed"); seedPath != "" {
+		seed := &seedSpec{}
+		seed.pod, _ = cmd.Flags().GetString("pod")
+		seed.agentHost, _ = cmd.Flags().GetString("agent-host")
+		seed.pipelineConfig, _ = cmd.Flags().GetString("pipeline-config")
+		seed.chassisConfig, _ = cmd.Flags().GetString("chassis-config")
+		if err = ioutil.WriteFile(seedPath, discoverySeed(spec, seed, topo), 0755); err != nil {
+			return err
+		}
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err = cli.GetOutput().Write(data)
+		return err
+	}
+	return ioutil.WriteFile(args[0], data, 0644)
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"strings"
+	"testing"
+
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateLeafSpine(t *testing.T) {
+	spec := &leafSpineSpec{spines: 2, leaves: 4, hostsPerLeaf: 8, portCount: 32, firstAgentPort: 20001}
+	topo, err := generateLeafSpine(spec)
+	assert.NoError(t, err)
+	assert.Len(t, topo.Devices, 6)
+	assert.Len(t, topo.Links, 8)
+	assert.Len(t, topo.Hosts, 32)
+	assert.NoError(t, validateTopology(topo, map[simapi.PortID]bool{}))
+	assert.Equal(t, &topoLink{Src: "leaf3/2", Tgt: "spine2/3", Bidirectional: true}, topo.Links[5])
+	assert.Equal(t, uint16(20006), topo.Devices[5].AgentPort)
+
+	macs := make(map[string]bool)
+	ips := make(map[string]bool)
+	ports := make(map[string]bool)
+	for _, h := range topo.Hosts {
+		nic := h.NICs[0]
+		assert.False(t, macs[nic.MAC] || ips[nic.IP] || ports[nic.Port], h.ID)
+		macs[nic.MAC], ips[nic.IP], ports[nic.Port] = true, true, true
+	}
+	assert.Equal(t, "leaf1/3", topo.Hosts[0].NICs[0].Port)
+
+	spec.ipus = true
+	topo, err = generateLeafSpine(spec)
+	assert.NoError(t, err)
+	assert.Len(t, topo.Devices, 38)
+	assert.Len(t, topo.Links, 40)
+	assert.NoError(t, validateTopology(topo, map[simapi.PortID]bool{}))
+	assert.Equal(t, "ipu1-1/2", topo.Hosts[0].NICs[0].Port)
+
+	seed := string(discoverySeed(spec, &seedSpec{pod: "pod1", agentHost: "fabric-sim"}, topo))
+	assert.Contains(t, seed, "onos discovery add switch spine1 --pod pod1 --rack spines --p4rt-endpoint fabric-sim:20001")
+	assert.Contains(t, seed, "onos discovery add ipu ipu4-8 --pod pod1 --rack rack4")
+	assert.Equal(t, 38, strings.Count(seed, "--p4rt-endpoint"))
+
+	_, err = generateLeafSpine(&leafSpineSpec{spines: 2, leaves: 4, hostsPerLeaf: 31, portCount: 32, firstAgentPort: 20001})
+	assert.Error(t, err)
+	_, err = generateLeafSpine(&leafSpineSpec{spines: 0, leaves: 4, portCount: 32, firstAgentPort: 20001})
+	assert.Error(t, err)
+}
@@ -8,19 +8,27 @@ import (
 	"context"
 	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 func createHostCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "host <id> [field options]",
+		Use:   "host <id> --nic <nic-spec> [--nic <nic-spec>...]",
 		Short: "Create a new simulated host",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runCreateHostCommand,
+		Long: `Create a new simulated host with one or more NICs, each attached to a port of a simulated device.
+A NIC is given as a comma-separated list of key=value fields, with the keys port and mac required,
+and ip, ipv6 and vlan optional, e.g. --nic port=leaf1/10,mac=0a:00:00:00:01:01,ip=10.10.1.1`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCreateHostCommand,
 	}
-	// TODO: Add appropriate options
+	cmd.Flags().StringArray("nic", nil, "NIC as port=<port-id>,mac=<mac>[,ip=<ipv4>][,ipv6=<ipv6>][,vlan=<vlan>]; may be repeated")
+	_ = cmd.MarkFlagRequired("nic")
 
 	return cmd
 }
@@ -78,14 +86,129 @@ func getHostClient(cmd *cobra.Command) (simapi.HostServiceClient, *grpc.ClientCo
 	return simapi.NewHostServiceClient(conn), conn, nil
 }
 
-func runCreateHostCommand(_ *cobra.Command, _ []string) error {
-	//client, conn, err := getHostClient(cmd)
-	//if err != nil {
-	//	return err
-	//}
-	//defer conn.Close()
-	//
-	//id := simapi.HostID(args[0])
+// parseNIC parses a NIC given as a comma-separated list of key=value fields
+func parseNIC(spec string) (*simapi.NetworkInterface, error) {
+	nic := &simapi.NetworkInterface{}
+	for _, field := range strings.Split(spec, ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, errors.NewInvalid("invalid NIC field '%s'; expected key=value", field)
+		}
+		key, value := kv[0], kv[1]
+		switch key {
+		case "port":
+			nic.ID = simapi.PortID(value)
+		case "mac":
+			nic.MacAddress = value
+		case "ip":
+			nic.IpAddress = value
+		case "ipv6":
+			nic.Ipv6Address = value
+		case "vlan":
+			vlan, err := strconv.ParseUint(value, 10, 12)
+			if err != nil {
+				return nil, errors.NewInvalid("invalid VLAN %s", value)
+			}
+			nic.VLAN = uint32(vlan)
+		default:
+			return nil, errors.NewInvalid("unknown NIC field %s; expected port, mac, ip, ipv6 or vlan", key)
+		}
+	}
+	if err := checkNIC(nic); err != nil {
+		return nil, errors.NewInvalid("invalid NIC '%s': %v", spec, err)
+	}
+	return nic, nil
+}
+
+// checkNIC validates the addresses of a NIC and normalizes its MAC address
+func checkNIC(nic *simapi.NetworkInterface) error {
+	if nic.ID == "" || nic.MacAddress == "" {
+		return errors.NewInvalid("NIC requires both port and mac")
+	}
+	mac, err := net.ParseMAC(nic.MacAddress)
+	if err != nil {
+		return errors.NewInvalid("invalid MAC address %s", nic.MacAddress)
+	}
+	nic.MacAddress = mac.String()
+	if nic.IpAddress != "" {
+		if ip := net.ParseIP(nic.IpAddress); ip == nil || ip.To4() == nil {
+			return errors.NewInvalid("invalid IPv4 address %s", nic.IpAddress)
+		}
+	}
+	if nic.Ipv6Address != "" {
+		if ip := net.ParseIP(nic.Ipv6Address); ip == nil || ip.To4() != nil {
+			return errors.NewInvalid("invalid IPv6 address %s", nic.Ipv6Address)
+		}
+	}
+	if nic.VLAN > 4095 {
+		return errors.NewInvalid("invalid VLAN %d", nic.VLAN)
+	}
+	return nil
+}
+
+// parseNICs parses the given NICs, rejecting any port used by more than one of them
+func parseNICs(specs []string) ([]*simapi.NetworkInterface, error) {
+	nics := make([]*simapi.NetworkInterface, 0, len(specs))
+	ports := make(map[simapi.PortID]bool)
+	for _, spec := range specs {
+		nic, err := parseNIC(spec)
+		if err != nil {
+			return nil, err
+		}
+		if ports[nic.ID] {
+			return nil, errors.NewInvalid("port %s is used by more than one NIC", nic.ID)
+		}
+		ports[nic.ID] = true
+		nics = append(nics, nic)
+	}
+	return nics, nil
+}
+
+// validateNICPorts checks that the ports of the NICs exist on the simulated devices
+func validateNICPorts(conn *grpc.ClientConn, nics []*simapi.NetworkInterface) error {
+	resp, err := simapi.NewDeviceServiceClient(conn).GetDevices(context.Background(), &simapi.GetDevicesRequest{})
+	if err != nil {
+		return err
+	}
+	ports := make(map[simapi.PortID]bool)
+	for _, d := range resp.Devices {
+		for _, p := range d.Ports {
+			ports[p.ID] = true
+		}
+	}
+	for _, nic := range nics {
+		if !ports[nic.ID] {
+			return errors.NewNotFound("port %s not found on any simulated device", nic.ID)
+		}
+	}
+	return nil
+}
+
+func runCreateHostCommand(cmd *cobra.Command, args []string) error {
+	specs, _ := cmd.Flags().GetStringArray("nic")
+	nics, err := parseNICs(specs)
+	if err != nil {
+		return err
+	}
+
+	client, conn, err := getHostClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = validateNICPorts(conn, nics); err != nil {
+		return err
+	}
+
+	host := &simapi.Host{
+		ID:         simapi.HostID(args[0]),
+		Interfaces: nics,
+	}
+	if _, err = client.AddHost(context.Background(), &simapi.AddHostRequest{Host: host}); err != nil {
+		cli.Output("Unable to create host: %+v", err)
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"testing"
+
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseNIC(t *testing.T) {
+	nic, err := parseNIC("port=leaf1/10,mac=0A:00:00:00:01:01,ip=10.10.1.1,ipv6=2001:db8::1,vlan=100")
+	assert.NoError(t, err)
+	assert.Equal(t, simapi.PortID("leaf1/10"), nic.ID)
+	assert.Equal(t, "0a:00:00:00:01:01", nic.MacAddress)
+	assert.Equal(t, "10.10.1.1", nic.IpAddress)
+	assert.Equal(t, "2001:db8::1", nic.Ipv6Address)
+	assert.Equal(t, uint32(100), nic.VLAN)
+
+	for _, spec := range []string{
+		"mac=0a:00:00:00:01:01",
+		"port=leaf1/10",
+		"port=leaf1/10,mac=bogus",
+		"port=leaf1/10,mac=0a:00:00:00:01:01,ip=2001:db8::1",
+		"port=leaf1/10,mac=0a:00:00:00:01:01,ipv6=10.10.1.1",
+		"port=leaf1/10,mac=0a:00:00:00:01:01,vlan=4096",
+		"port=leaf1/10,mac=0a:00:00:00:01:01,speed=10G",
+		"port=leaf1/10,mac",
+	} {
+		_, err = parseNIC(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func Test_ParseNICs(t *testing.T) {
+	nics, err := parseNICs([]string{"port=leaf1/10,mac=0a:00:00:00:01:01", "port=leaf2/10,mac=0a:00:00:00:01:02"})
+	assert.NoError(t, err)
+	assert.Len(t, nics, 2)
+
+	_, err = parseNICs([]string{"port=leaf1/10,mac=0a:00:00:00:01:01", "port=leaf1/10,mac=0a:00:00:00:01:02"})
+	assert.Error(t, err)
+}
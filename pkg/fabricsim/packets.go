@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+const etherTypeLLDP = 0x88cc
+
+var lldpMAC = net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e}
+
+// ethernetFrame prepends an Ethernet header to the payload
+func ethernetFrame(dst net.HardwareAddr, src net.HardwareAddr, etherType uint16, payload []byte) []byte {
+	frame := make([]byte, 14, 14+len(payload))
+	copy(frame[0:6], dst)
+	copy(frame[6:12], src)
+	binary.BigEndian.PutUint16(frame[12:14], etherType)
+	return append(frame, payload...)
+}
+
+// lldpTLV encodes an LLDP TLV
+func lldpTLV(tlvType byte, value []byte) []byte {
+	tlv := make([]byte, 2, 2+len(value))
+	binary.BigEndian.PutUint16(tlv, uint16(tlvType)<<9|uint16(len(value)))
+	return append(tlv, value...)
+}
+
+// lldpFrame builds an LLDP frame advertising the given locally assigned chassis and port IDs
+func lldpFrame(mac net.HardwareAddr, chassisID string, portID string, ttl uint16) []byte {
+	const locallyAssigned = 7
+	payload := lldpTLV(1, append([]byte{locallyAssigned}, chassisID...))
+	payload = append(payload, lldpTLV(2, append([]byte{locallyAssigned}, portID...))...)
+	payload = append(payload, lldpTLV(3, []byte{byte(ttl >> 8), byte(ttl)})...)
+	payload = append(payload, lldpTLV(0, nil)...)
+	return ethernetFrame(lldpMAC, mac, etherTypeLLDP, payload)
+}
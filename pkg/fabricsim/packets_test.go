@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testMAC = net.HardwareAddr{0x0a, 0, 0, 0, 0x01, 0x02}
+
+func Test_LLDPFrame(t *testing.T) {
+	frame := lldpFrame(testMAC, "spine1", "3", 120)
+	assert.Equal(t, []byte(lldpMAC), frame[0:6])
+	assert.Equal(t, uint16(etherTypeLLDP), binary.BigEndian.Uint16(frame[12:14]))
+	assert.Equal(t, []byte{0x02, 0x07, 7, 's', 'p', 'i', 'n', 'e', '1'}, frame[14:23])
+	assert.Equal(t, []byte{0x04, 0x02, 7, '3'}, frame[23:27])
+	assert.Equal(t, []byte{0x06, 0x02, 0, 120, 0, 0}, frame[27:])
+}
@@ -28,7 +28,7 @@ func Init() {
 // GetCommand returns the root command for the RAN service
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "fabric-sim {create,delete,get,start,stop,enable,disable,emit} [args]",
+		Use:     "fabric-sim {create,delete,get,start,stop,enable,disable,emit,load,export,generate,fail,flap,verify-topo} [args]",
 		Short:   "ONOS fabric simulator commands",
 		Aliases: []string{"fabricsim", "fabsim", "fsim"},
 	}
@@ -47,13 +47,21 @@ func GetCommand() *cobra.Command {
 	cmd.AddCommand(getDisableCommand())
 	cmd.AddCommand(getEmitCommand())
 
+	cmd.AddCommand(loadCommand())
+	cmd.AddCommand(exportCommand())
+	cmd.AddCommand(getGenerateCommand())
+
+	cmd.AddCommand(getFailCommand())
+	cmd.AddCommand(flapCommand())
+	cmd.AddCommand(verifyTopoCommand())
+
 	cmd.AddCommand(loglib.GetCommand())
 	return cmd
 }
 
 func getCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create {device,link} [args]",
+		Use:   "create {device,link,host} [args]",
 		Short: "Commands for creating simulated entities",
 	}
 
@@ -65,7 +73,7 @@ func getCreateCommand() *cobra.Command {
 
 func getDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete {device,link} [args]",
+		Use:   "delete {device,link,host} [args]",
 		Short: "Commands for deleting simulated entities",
 	}
 
@@ -134,12 +142,26 @@ func getDisableCommand() *cobra.Command {
 	return cmd
 }
 
+func getFailCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fail {link} [args]",
+		Short: "Commands for failing simulated entities",
+	}
+
+	cmd.AddCommand(failLinkCommand())
+	return cmd
+}
+
 func getEmitCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "emit {arp} [args]",
-		Short: "Emit ARP, DHCP requests, etc",
+		Use:   "emit {arp,lldp} [args]",
+		Short: "Emit ARP requests and LLDP packets",
+		Long: `Emit ARP requests from simulated hosts and LLDP packets as received on device ports.
+DHCP and NDP requests and the replay of raw packet captures are not supported, since the simulator
+offers no call to emit arbitrary packets from a host.`,
 	}
 
 	cmd.AddCommand(emitARPsCommand())
+	cmd.AddCommand(emitLLDPCommand())
 	return cmd
 }
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"context"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-api/go/onos/misc"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"sort"
+	"time"
+)
+
+// deviceRate holds the I/O of a device between two polls of its stats
+type deviceRate struct {
+	id          simapi.DeviceID
+	inMessages  uint32
+	outMessages uint32
+	inBytes     uint32
+	outBytes    uint32
+	seconds     float64
+}
+
+func (r *deviceRate) messages() uint32 {
+	return r.inMessages + r.outMessages
+}
+
+func (r *deviceRate) perSecond(n uint32) float64 {
+	if r.seconds <= 0 {
+		return 0
+	}
+	return float64(n) / r.seconds
+}
+
+func addStatsWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "poll the stats and show per-device and aggregate rates")
+	cmd.Flags().Duration("interval", 2*time.Second, "interval between polls of the stats")
+	cmd.Flags().Int("top", 0, "show only the N devices with the most messages per second; implies sampling for one interval")
+}
+
+// statsDelta returns the counter increments from the previous to the current stats; counters are
+// 32-bit and wrap around, and start over when the device restarts
+func statsDelta(id simapi.DeviceID, previous *misc.IOStats, current *misc.IOStats, seconds float64) *deviceRate {
+	rate := &deviceRate{id: id, seconds: seconds}
+	if current == nil {
+		return rate
+	}
+	if previous == nil || previous.FirstUpdateTime != current.FirstUpdateTime {
+		previous = &misc.IOStats{}
+	}
+	rate.inMessages = current.InMessages - previous.InMessages
+	rate.outMessages = current.OutMessages - previous.OutMessages
+	rate.inBytes = current.InBytes - previous.InBytes
+	rate.outBytes = current.OutBytes - previous.OutBytes
+	return rate
+}
+
+// deviceRates computes the rates of the devices from two polls of their stats, busiest first if top
+// is positive, and otherwise ordered by device ID
+func deviceRates(previous map[simapi.DeviceID]*misc.IOStats, devices []*simapi.Device, seconds float64, top int) []*deviceRate {
+	rates := make([]*deviceRate, 0, len(devices))
+	for _, d := range devices {
+		rates = append(rates, statsDelta(d.ID, previous[d.ID], d.IOStats, seconds))
+	}
+	if top > 0 {
+		sort.SliceStable(rates, func(i, j int) bool {
+			if rates[i].messages() != rates[j].messages() {
+				return rates[i].messages() > rates[j].messages()
+			}
+			return rates[i].id < rates[j].id
+		})
+		if len(rates) > top {
+			rates = rates[:top]
+		}
+	} else {
+		sort.SliceStable(rates, func(i, j int) bool { return rates[i].id < rates[j].id })
+	}
+	return rates
+}
+
+// totalRate sums the I/O of all devices
+func totalRate(rates []*deviceRate, seconds float64) *deviceRate {
+	total := &deviceRate{id: "Total", seconds: seconds}
+	for _, r := range rates {
+		total.inMessages += r.inMessages
+		total.outMessages += r.outMessages
+		total.inBytes += r.inBytes
+		total.outBytes += r.outBytes
+	}
+	return total
+}
+
+func printRateHeaders(noHeaders bool) {
+	if !noHeaders {
+		cli.Output("%-16s %12s %12s %12s %12s %10s %10s\n",
+			"ID", "In Msgs/s", "Out Msgs/s", "In Bytes/s", "Out Bytes/s", "Δ In Msgs", "Δ Out Msgs")
+	}
+}
+
+func printRate(r *deviceRate) {
+	cli.Output("%-16s %12.1f %12.1f %12.1f %12.1f %10d %10d\n", r.id,
+		r.perSecond(r.inMessages), r.perSecond(r.outMessages), r.perSecond(r.inBytes), r.perSecond(r.outBytes),
+		r.inMessages, r.outMessages)
+}
+
+func pollDeviceStats(client simapi.DeviceServiceClient) ([]*simapi.Device, map[simapi.DeviceID]*misc.IOStats, error) {
+	resp, err := client.GetDevices(context.Background(), &simapi.GetDevicesRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	stats := make(map[simapi.DeviceID]*misc.IOStats, len(resp.Devices))
+	for _, d := range resp.Devices {
+		stats[d.ID] = d.IOStats
+	}
+	return resp.Devices, stats, nil
+}
+
+// runWatchStats polls the device stats every interval and prints the per-device and aggregate rates;
+// unless watching, it prints the rates of a single interval
+func runWatchStats(cmd *cobra.Command) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	top, _ := cmd.Flags().GetInt("top")
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+	if interval <= 0 {
+		return errors.NewInvalid("--interval must be positive")
+	}
+	if top < 0 {
+		return errors.NewInvalid("--top must not be negative")
+	}
+
+	client, conn, err := getDeviceClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, previous, err := pollDeviceStats(client)
+	if err != nil {
+		return err
+	}
+	last := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		devices, current, err := pollDeviceStats(client)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		seconds := now.Sub(last).Seconds()
+
+		all := deviceRates(previous, devices, seconds, 0)
+		rates := all
+		if top > 0 {
+			rates = deviceRates(previous, devices, seconds, top)
+		}
+		if watch {
+			cli.Output("%s\n", now.Format(time.Stamp))
+		}
+		printRateHeaders(noHeaders)
+		for _, r := range rates {
+			printRate(r)
+		}
+		printRate(totalRate(all, seconds))
+		if !watch {
+			return nil
+		}
+		cli.Output("\n")
+		previous, last = current, now
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"math"
+	"testing"
+
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-api/go/onos/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StatsDelta(t *testing.T) {
+	previous := &misc.IOStats{InMessages: 10, OutMessages: math.MaxUint32 - 4, InBytes: 100, OutBytes: 200, FirstUpdateTime: 1}
+	current := &misc.IOStats{InMessages: 30, OutMessages: 5, InBytes: 300, OutBytes: 400, FirstUpdateTime: 1}
+	rate := statsDelta("leaf1", previous, current, 2)
+	assert.Equal(t, uint32(20), rate.inMessages)
+	assert.Equal(t, uint32(10), rate.outMessages)
+	assert.Equal(t, 10.0, rate.perSecond(rate.inMessages))
+	assert.Equal(t, 100.0, rate.perSecond(rate.outBytes))
+
+	// A restarted device starts its counters over
+	current.FirstUpdateTime = 2
+	rate = statsDelta("leaf1", previous, current, 2)
+	assert.Equal(t, uint32(30), rate.inMessages)
+
+	rate = statsDelta("leaf1", nil, nil, 2)
+	assert.Equal(t, uint32(0), rate.messages())
+}
+
+func Test_DeviceRates(t *testing.T) {
+	previous := map[simapi.DeviceID]*misc.IOStats{
+		"leaf1":  {InMessages: 10},
+		"leaf2":  {InMessages: 10},
+		"spine1": {InMessages: 10},
+	}
+	devices := []*simapi.Device{
+		{ID: "spine1", IOStats: &misc.IOStats{InMessages: 50, OutMessages: 10}},
+		{ID: "leaf2", IOStats: &misc.IOStats{InMessages: 10}},
+		{ID: "leaf1", IOStats: &misc.IOStats{InMessages: 20, OutMessages: 20}},
+		{ID: "leaf3", IOStats: &misc.IOStats{InMessages: 5}},
+	}
+
+	rates := deviceRates(previous, devices, 1, 0)
+	assert.Len(t, rates, 4)
+	assert.Equal(t, simapi.DeviceID("leaf1"), rates[0].id)
+
+	rates = deviceRates(previous, devices, 1, 2)
+	assert.Len(t, rates, 2)
+	assert.Equal(t, simapi.DeviceID("spine1"), rates[0].id)
+	assert.Equal(t, uint32(50), rates[0].messages())
+	assert.Equal(t, simapi.DeviceID("leaf1"), rates[1].id)
+
+	total := totalRate(deviceRates(previous, devices, 1, 0), 1)
+	assert.Equal(t, uint32(85), total.messages())
+}
@@ -0,0 +1,426 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"context"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// topology is the declarative description of a simulated fabric
+type topology struct {
+	Devices []*topoDevice `yaml:"devices"`
+	Links   []*topoLink   `yaml:"links"`
+	Hosts   []*topoHost   `yaml:"hosts"`
+}
+
+// topoDevice describes its ports either individually or, for ports numbered <id>/1 through <id>/N, by their count
+type topoDevice struct {
+	ID        string      `yaml:"id"`
+	Type      string      `yaml:"type"`
+	AgentPort uint16      `yaml:"agentPort"`
+	PortCount uint16      `yaml:"portCount,omitempty"`
+	Ports     []*topoPort `yaml:"ports,omitempty"`
+}
+
+type topoPort struct {
+	ID             string `yaml:"id"`
+	Number         uint32 `yaml:"number"`
+	Name           string `yaml:"name,omitempty"`
+	InternalNumber uint32 `yaml:"internalNumber,omitempty"`
+	Speed          string `yaml:"speed,omitempty"`
+}
+
+type topoLink struct {
+	Src           string `yaml:"src"`
+	Tgt           string `yaml:"tgt"`
+	Bidirectional bool   `yaml:"bidirectional"`
+}
+
+type topoHost struct {
+	ID   string     `yaml:"id"`
+	NICs []*topoNIC `yaml:"nics"`
+}
+
+type topoNIC struct {
+	Port string `yaml:"port"`
+	MAC  string `yaml:"mac"`
+	IP   string `yaml:"ip,omitempty"`
+	IPv6 string `yaml:"ipv6,omitempty"`
+	VLAN uint32 `yaml:"vlan,omitempty"`
+}
+
+func loadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load <topology.yaml>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Create the devices, links and hosts of a topology YAML file",
+		Long: `Create the devices, links and hosts described by a topology YAML file, as written by the export command.
+Devices either list their ports or give a portCount, in which case their ports are numbered <device>/1 and up.
+Entities that already exist are skipped, so loading the same file again only creates what is missing.`,
+		RunE: runLoadCommand,
+	}
+	cmd.Flags().Bool("start-agents", true, "starts the agents of the created devices")
+	return cmd
+}
+
+func exportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [<topology.yaml>|-]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Export the simulated devices, links and hosts as a topology YAML file",
+		Long: `Export the simulated devices, links and hosts as a topology YAML file that can be given to the load command.
+Devices are exported with the IDs and numbers of their ports; pairs of inverse links are exported as one bidirectional link.`,
+		RunE: runExportCommand,
+	}
+	return cmd
+}
+
+// simPorts returns the ports of the device, as listed or as generated from the port count
+func (d *topoDevice) simPorts() []*simapi.Port {
+	if len(d.Ports) == 0 {
+		return devicePorts(simapi.DeviceID(d.ID), d.PortCount)
+	}
+	ports := make([]*simapi.Port, 0, len(d.Ports))
+	for _, p := range d.Ports {
+		ports = append(ports, &simapi.Port{
+			ID:             simapi.PortID(p.ID),
+			Name:           p.Name,
+			Number:         p.Number,
+			InternalNumber: p.InternalNumber,
+			Speed:          p.Speed,
+		})
+	}
+	return ports
+}
+
+func toSimDevice(d *topoDevice) *simapi.Device {
+	deviceType := simapi.DeviceType_SWITCH
+	if strings.EqualFold(d.Type, "IPU") {
+		deviceType = simapi.DeviceType_IPU
+	}
+	id := simapi.DeviceID(d.ID)
+	return &simapi.Device{
+		ID:          id,
+		Type:        deviceType,
+		Ports:       d.simPorts(),
+		ControlPort: int32(d.AgentPort),
+	}
+}
+
+func toSimHost(h *topoHost) (*simapi.Host, error) {
+	host := &simapi.Host{ID: simapi.HostID(h.ID)}
+	for _, n := range h.NICs {
+		nic := &simapi.NetworkInterface{
+			ID:          simapi.PortID(n.Port),
+			MacAddress:  n.MAC,
+			IpAddress:   n.IP,
+			Ipv6Address: n.IPv6,
+			VLAN:        n.VLAN,
+		}
+		if err := checkNIC(nic); err != nil {
+			return nil, errors.NewInvalid("host %s: %v", h.ID, err)
+		}
+		host.Interfaces = append(host.Interfaces, nic)
+	}
+	return host, nil
+}
+
+// validateTopology checks the topology for duplicate and missing entities; the given ports are those of
+// the devices that already exist
+func validateTopology(topo *topology, ports map[simapi.PortID]bool) error {
+	devices := make(map[string]bool)
+	topoPorts := make(map[simapi.PortID]bool)
+	for _, d := range topo.Devices {
+		if d.ID == "" {
+			return errors.NewInvalid("device without id")
+		}
+		if devices[d.ID] {
+			return errors.NewInvalid("duplicate device %s", d.ID)
+		}
+		if d.Type != "" && !strings.EqualFold(d.Type, "switch") && !strings.EqualFold(d.Type, "IPU") {
+			return errors.NewInvalid("device %s: invalid type %s; expected switch or IPU", d.ID, d.Type)
+		}
+		devices[d.ID] = true
+		if d.PortCount > 0 && len(d.Ports) > 0 {
+			return errors.NewInvalid("device %s: either ports or portCount may be given", d.ID)
+		}
+		numbers := make(map[uint32]bool)
+		for _, p := range d.simPorts() {
+			if p.ID == "" {
+				return errors.NewInvalid("device %s: port without id", d.ID)
+			}
+			if topoPorts[p.ID] || numbers[p.Number] {
+				return errors.NewInvalid("device %s: duplicate port %s or port number %d", d.ID, p.ID, p.Number)
+			}
+			topoPorts[p.ID] = true
+			numbers[p.Number] = true
+			ports[p.ID] = true
+		}
+	}
+	for _, l := range topo.Links {
+		for _, p := range []string{l.Src, l.Tgt} {
+			if !ports[simapi.PortID(p)] {
+				return errors.NewNotFound("link %s-%s: port %s not found", l.Src, l.Tgt, p)
+			}
+		}
+	}
+	hosts := make(map[string]bool)
+	for _, h := range topo.Hosts {
+		if h.ID == "" {
+			return errors.NewInvalid("host without id")
+		}
+		if hosts[h.ID] {
+			return errors.NewInvalid("duplicate host %s", h.ID)
+		}
+		hosts[h.ID] = true
+		if len(h.NICs) == 0 {
+			return errors.NewInvalid("host %s has no NICs", h.ID)
+		}
+		for _, n := range h.NICs {
+			if !ports[simapi.PortID(n.Port)] {
+				return errors.NewNotFound("host %s: port %s not found", h.ID, n.Port)
+			}
+		}
+	}
+	return nil
+}
+
+// loadCounts tallies the created and skipped entities of one kind
+type loadCounts struct {
+	created int
+	skipped int
+}
+
+func (c *loadCounts) add(created bool, kind string, id string) {
+	if created {
+		c.created++
+		cli.Output("Created %s %s\n", kind, id)
+	} else {
+		c.skipped++
+		cli.Output("Skipped %s %s; already exists\n", kind, id)
+	}
+}
+
+func runLoadCommand(cmd *cobra.Command, args []string) error {
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	topo := &topology{}
+	if err = yaml.UnmarshalStrict(data, topo); err != nil {
+		return err
+	}
+	startAgents, _ := cmd.Flags().GetBool("start-agents")
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	deviceClient := simapi.NewDeviceServiceClient(conn)
+	linkClient := simapi.NewLinkServiceClient(conn)
+	hostClient := simapi.NewHostServiceClient(conn)
+
+	liveDevices, liveLinks, liveHosts, err := getLiveEntities(conn)
+	if err != nil {
+		return err
+	}
+	existingDevices := make(map[simapi.DeviceID]bool)
+	ports := make(map[simapi.PortID]bool)
+	for _, d := range liveDevices {
+		existingDevices[d.ID] = true
+		for _, p := range d.Ports {
+			ports[p.ID] = true
+		}
+	}
+	existingLinks := make(map[simapi.LinkID]bool)
+	for _, l := range liveLinks {
+		existingLinks[l.ID] = true
+	}
+	existingHosts := make(map[simapi.HostID]bool)
+	for _, h := range liveHosts {
+		existingHosts[h.ID] = true
+	}
+
+	if err = validateTopology(topo, ports); err != nil {
+		return err
+	}
+	hosts := make([]*simapi.Host, 0, len(topo.Hosts))
+	for _, h := range topo.Hosts {
+		host, err := toSimHost(h)
+		if err != nil {
+			return err
+		}
+		hosts = append(hosts, host)
+	}
+
+	var devices, links, hostCounts loadCounts
+	for _, d := range topo.Devices {
+		device := toSimDevice(d)
+		if existingDevices[device.ID] {
+			devices.add(false, "device", string(device.ID))
+			continue
+		}
+		if _, err = deviceClient.AddDevice(ctx, &simapi.AddDeviceRequest{Device: device}); err != nil {
+			cli.Output("Unable to create device: %+v", err)
+			return err
+		}
+		if startAgents {
+			if _, err = deviceClient.StartDevice(ctx, &simapi.StartDeviceRequest{ID: device.ID}); err != nil {
+				cli.Output("Unable to start device agent: %+v", err)
+				return err
+			}
+		}
+		devices.add(true, "device", string(device.ID))
+	}
+
+	for _, l := range topo.Links {
+		ends := [][2]simapi.PortID{{simapi.PortID(l.Src), simapi.PortID(l.Tgt)}}
+		if l.Bidirectional {
+			ends = append(ends, [2]simapi.PortID{simapi.PortID(l.Tgt), simapi.PortID(l.Src)})
+		}
+		for _, e := range ends {
+			id := simapi.NewLinkID(e[0], e[1])
+			if existingLinks[id] {
+				links.add(false, "link", string(id))
+				continue
+			}
+			if err = createLink(linkClient, id, e[0], e[1]); err != nil {
+				return err
+			}
+			existingLinks[id] = true
+			links.add(true, "link", string(id))
+		}
+	}
+
+	for _, host := range hosts {
+		if existingHosts[host.ID] {
+			hostCounts.add(false, "host", string(host.ID))
+			continue
+		}
+		if _, err = hostClient.AddHost(ctx, &simapi.AddHostRequest{Host: host}); err != nil {
+			cli.Output("Unable to create host: %+v", err)
+			return err
+		}
+		hostCounts.add(true, "host", string(host.ID))
+	}
+
+	cli.Output("Devices: %d created, %d skipped\nLinks: %d created, %d skipped\nHosts: %d created, %d skipped\n",
+		devices.created, devices.skipped, links.created, links.skipped, hostCounts.created, hostCounts.skipped)
+	return nil
+}
+
+// toTopology describes the given simulated entities as a topology, merging pairs of inverse links
+func toTopology(devices []*simapi.Device, links []*simapi.Link, hosts []*simapi.Host) *topology {
+	topo := &topology{
+		Devices: make([]*topoDevice, 0, len(devices)),
+		Links:   make([]*topoLink, 0, len(links)),
+		Hosts:   make([]*topoHost, 0, len(hosts)),
+	}
+
+	sort.SliceStable(devices, func(i, j int) bool { return devices[i].ID < devices[j].ID })
+	for _, d := range devices {
+		deviceType := "switch"
+		if d.Type == simapi.DeviceType_IPU {
+			deviceType = "IPU"
+		}
+		device := &topoDevice{
+			ID:        string(d.ID),
+			Type:      deviceType,
+			AgentPort: uint16(d.ControlPort),
+			Ports:     make([]*topoPort, 0, len(d.Ports)),
+		}
+		for _, p := range d.Ports {
+			device.Ports = append(device.Ports, &topoPort{
+				ID:             string(p.ID),
+				Number:         p.Number,
+				Name:           p.Name,
+				InternalNumber: p.InternalNumber,
+				Speed:          p.Speed,
+			})
+		}
+		topo.Devices = append(topo.Devices, device)
+	}
+
+	sort.SliceStable(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	exported := make(map[simapi.LinkID]*topoLink)
+	for _, l := range links {
+		if inverse, ok := exported[simapi.NewLinkID(l.TgtID, l.SrcID)]; ok && !inverse.Bidirectional {
+			inverse.Bidirectional = true
+			continue
+		}
+		link := &topoLink{Src: string(l.SrcID), Tgt: string(l.TgtID)}
+		exported[l.ID] = link
+		topo.Links = append(topo.Links, link)
+	}
+
+	sort.SliceStable(hosts, func(i, j int) bool { return hosts[i].ID < hosts[j].ID })
+	for _, h := range hosts {
+		host := &topoHost{ID: string(h.ID)}
+		for _, n := range h.Interfaces {
+			host.NICs = append(host.NICs, &topoNIC{
+				Port: string(n.ID),
+				MAC:  n.MacAddress,
+				IP:   n.IpAddress,
+				IPv6: n.Ipv6Address,
+				VLAN: n.VLAN,
+			})
+		}
+		topo.Hosts = append(topo.Hosts, host)
+	}
+	return topo
+}
+
+// getLiveEntities retrieves the simulated devices, links and hosts
+func getLiveEntities(conn *grpc.ClientConn) ([]*simapi.Device, []*simapi.Link, []*simapi.Host, error) {
+	ctx := context.Background()
+	devicesResp, err := simapi.NewDeviceServiceClient(conn).GetDevices(ctx, &simapi.GetDevicesRequest{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	linksResp, err := simapi.NewLinkServiceClient(conn).GetLinks(ctx, &simapi.GetLinksRequest{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	hostsResp, err := simapi.NewHostServiceClient(conn).GetHosts(ctx, &simapi.GetHostsRequest{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return devicesResp.Devices, linksResp.Links, hostsResp.Hosts, nil
+}
+
+func runExportCommand(cmd *cobra.Command, args []string) error {
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	devices, links, hosts, err := getLiveEntities(conn)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(toTopology(devices, links, hosts))
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err = cli.GetOutput().Write(data)
+		return err
+	}
+	return ioutil.WriteFile(args[0], data, 0644)
+}
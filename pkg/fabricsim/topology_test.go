@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"testing"
+
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const testTopology = `devices:
+  - id: spine1
+    type: switch
+    agentPort: 20001
+    portCount: 4
+  - id: leaf1
+    type: switch
+    agentPort: 20002
+    portCount: 4
+links:
+  - src: spine1/1
+    tgt: leaf1/1
+    bidirectional: true
+hosts:
+  - id: host1
+    nics:
+      - port: leaf1/4
+        mac: 0A:00:00:00:01:01
+        ip: 10.10.1.1
+`
+
+func Test_ValidateTopology(t *testing.T) {
+	topo := &topology{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testTopology), topo))
+	assert.NoError(t, validateTopology(topo, map[simapi.PortID]bool{}))
+
+	host, err := toSimHost(topo.Hosts[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "0a:00:00:00:01:01", host.Interfaces[0].MacAddress)
+
+	topo.Links[0].Tgt = "leaf1/5"
+	assert.Error(t, validateTopology(topo, map[simapi.PortID]bool{}))
+	assert.NoError(t, validateTopology(topo, map[simapi.PortID]bool{"leaf1/5": true}))
+
+	topo.Devices = append(topo.Devices, &topoDevice{ID: "leaf1"})
+	assert.Error(t, validateTopology(topo, map[simapi.PortID]bool{"leaf1/5": true}))
+}
+
+func Test_ToTopology(t *testing.T) {
+	topo := &topology{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(testTopology), topo))
+
+	devices := make([]*simapi.Device, 0)
+	for _, d := range topo.Devices {
+		devices = append(devices, toSimDevice(d))
+	}
+	links := []*simapi.Link{
+		{ID: simapi.NewLinkID("spine1/1", "leaf1/1"), SrcID: "spine1/1", TgtID: "leaf1/1"},
+		{ID: simapi.NewLinkID("leaf1/1", "spine1/1"), SrcID: "leaf1/1", TgtID: "spine1/1"},
+		{ID: simapi.NewLinkID("spine1/2", "leaf1/2"), SrcID: "spine1/2", TgtID: "leaf1/2"},
+	}
+	host, err := toSimHost(topo.Hosts[0])
+	assert.NoError(t, err)
+
+	exported := toTopology(devices, links, []*simapi.Host{host})
+	assert.Len(t, exported.Devices, 2)
+	assert.Equal(t, "leaf1", exported.Devices[0].ID)
+	assert.Equal(t, uint16(0), exported.Devices[0].PortCount)
+	assert.Len(t, exported.Devices[0].Ports, 4)
+	assert.Equal(t, &topoPort{ID: "leaf1/1", Number: 1, Name: "1", InternalNumber: 1025, Speed: "100Gbps"}, exported.Devices[0].Ports[0])
+	assert.Equal(t, uint16(20002), exported.Devices[0].AgentPort)
+
+	assert.Len(t, exported.Links, 2)
+	assert.Equal(t, &topoLink{Src: "leaf1/1", Tgt: "spine1/1", Bidirectional: true}, exported.Links[0])
+	assert.Equal(t, &topoLink{Src: "spine1/2", Tgt: "leaf1/2"}, exported.Links[1])
+
+	assert.Len(t, exported.Hosts, 1)
+	assert.Equal(t, "leaf1/4", exported.Hosts[0].NICs[0].Port)
+}
+
+func Test_TopologyPorts(t *testing.T) {
+	device := &simapi.Device{
+		ID: "tor1",
+		Ports: []*simapi.Port{
+			{ID: "tor1/eth0", Number: 7, Name: "eth0", Speed: "25Gbps"},
+			{ID: "tor1/eth1", Number: 9, Name: "eth1", Speed: "25Gbps"},
+		},
+	}
+	exported := toTopology([]*simapi.Device{device}, nil, nil)
+	data, err := yaml.Marshal(exported)
+	assert.NoError(t, err)
+
+	topo := &topology{}
+	assert.NoError(t, yaml.UnmarshalStrict(data, topo))
+	assert.NoError(t, validateTopology(topo, map[simapi.PortID]bool{}))
+	assert.Equal(t, device.Ports, toSimDevice(topo.Devices[0]).Ports)
+
+	topo.Devices[0].PortCount = 2
+	assert.Error(t, validateTopology(topo, map[simapi.PortID]bool{}))
+	topo.Devices[0].PortCount = 0
+	topo.Devices[0].Ports[1].Number = 7
+	assert.Error(t, validateTopology(topo, map[simapi.PortID]bool{}))
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"fmt"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/spf13/cobra"
+	"sort"
+	"strings"
+)
+
+const (
+	linkUp   = "up"
+	linkDown = "down"
+)
+
+// fabricView is the part of a fabric compared between the simulator and onos-topo; ports are keyed
+// as <device-id>/<port-number> and links as <src-port>-><tgt-port>
+type fabricView struct {
+	devices map[string]string
+	ports   map[string]bool
+	links   map[string]string
+}
+
+func newFabricView() *fabricView {
+	return &fabricView{
+		devices: make(map[string]string),
+		ports:   make(map[string]bool),
+		links:   make(map[string]string),
+	}
+}
+
+// verifyFinding is a difference between the simulated fabric and onos-topo
+type verifyFinding struct {
+	result string
+	entity string
+	id     string
+	detail string
+}
+
+func verifyTopoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-topo",
+		Args:  cobra.NoArgs,
+		Short: "Verify that onos-topo contains the simulated devices, ports and links",
+		Long: `Verify that onos-topo contains exactly the simulated devices, ports and links. Switch and IPU entities are
+expected to have the IDs of the simulated devices, ports to be contained by them with the port numbers
+of the simulated ports, and links to be relations between those ports. Missing, extra and mismatched
+entities are reported, including differences in device kind, port enabled state and link status; the
+command fails if there are any.`,
+		RunE: runVerifyTopoCommand,
+	}
+	cmd.Flags().String("topo-address", "onos-topo:5150", "onos-topo address")
+	cmd.Flags().Bool("no-headers", false, "disables output headers")
+	return cmd
+}
+
+func portKey(deviceID string, number uint32) string {
+	return fmt.Sprintf("%s/%d", deviceID, number)
+}
+
+func linkKey(src string, tgt string) string {
+	return fmt.Sprintf("%s->%s", src, tgt)
+}
+
+// simFabricView describes the simulated devices and links
+func simFabricView(devices []*simapi.Device, links []*simapi.Link) *fabricView {
+	view := newFabricView()
+	ports := make(map[simapi.PortID]string)
+	for _, d := range devices {
+		kind := topoapi.SwitchKind
+		if d.Type == simapi.DeviceType_IPU {
+			kind = topoapi.IPUKind
+		}
+		view.devices[string(d.ID)] = kind
+		for _, p := range d.Ports {
+			key := portKey(string(d.ID), p.Number)
+			ports[p.ID] = key
+			view.ports[key] = p.Enabled
+		}
+	}
+	for _, l := range links {
+		src, tgt := ports[l.SrcID], ports[l.TgtID]
+		if src == "" || tgt == "" {
+			continue
+		}
+		status := linkDown
+		if l.Status == simapi.LinkStatus_LINK_UP {
+			status = linkUp
+		}
+		view.links[linkKey(src, tgt)] = status
+	}
+	return view
+}
+
+// topoFabricView describes the switches, IPUs, ports and links among the onos-topo objects
+func topoFabricView(objects []*topoapi.Object) *fabricView {
+	view := newFabricView()
+	portAspects := make(map[topoapi.ID]*topoapi.Port)
+	for _, o := range objects {
+		entity := o.GetEntity()
+		if entity == nil {
+			continue
+		}
+		switch entity.KindID {
+		case topoapi.SwitchKind, topoapi.IPUKind:
+			view.devices[string(o.ID)] = string(entity.KindID)
+		case topoapi.PortKind:
+			port := &topoapi.Port{}
+			if err := o.GetAspect(port); err == nil {
+				portAspects[o.ID] = port
+			}
+		}
+	}
+
+	ports := make(map[topoapi.ID]string)
+	for _, o := range objects {
+		relation := o.GetRelation()
+		if relation == nil || relation.KindID != topoapi.CONTAINS {
+			continue
+		}
+		if _, ok := view.devices[string(relation.SrcEntityID)]; !ok {
+			continue
+		}
+		if port, ok := portAspects[relation.TgtEntityID]; ok {
+			key := portKey(string(relation.SrcEntityID), port.Number)
+			ports[relation.TgtEntityID] = key
+			view.ports[key] = port.Enabled
+		}
+	}
+
+	for _, o := range objects {
+		relation := o.GetRelation()
+		if relation == nil || relation.KindID != topoapi.LinkKind {
+			continue
+		}
+		src, tgt := ports[relation.SrcEntityID], ports[relation.TgtEntityID]
+		if src == "" || tgt == "" {
+			continue
+		}
+		status := ""
+		link := &topoapi.Link{}
+		if err := o.GetAspect(link); err == nil && link.Status != "" {
+			status = linkDown
+			if strings.Contains(strings.ToUpper(link.Status), "UP") {
+				status = linkUp
+			}
+		}
+		view.links[linkKey(src, tgt)] = status
+	}
+	return view
+}
+
+// compareFabricViews reports the devices, ports and links that are missing from onos-topo, that it has in
+// excess, and that differ; onos-topo links without a status are not compared by status
+func compareFabricViews(sim *fabricView, topo *fabricView) []verifyFinding {
+	findings := make([]verifyFinding, 0)
+	for id, kind := range sim.devices {
+		if topoKind, ok := topo.devices[id]; !ok {
+			findings = append(findings, verifyFinding{"missing", "device", id, kind})
+		} else if topoKind != kind {
+			findings = append(findings, verifyFinding{"mismatch", "device", id, fmt.Sprintf("kind %s; expected %s", topoKind, kind)})
+		}
+	}
+	for id, kind := range topo.devices {
+		if _, ok := sim.devices[id]; !ok {
+			findings = append(findings, verifyFinding{"extra", "device", id, kind})
+		}
+	}
+
+	for key, enabled := range sim.ports {
+		if topoEnabled, ok := topo.ports[key]; !ok {
+			findings = append(findings, verifyFinding{"missing", "port", key, ""})
+		} else if topoEnabled != enabled {
+			findings = append(findings, verifyFinding{"mismatch", "port", key, fmt.Sprintf("enabled %t; expected %t", topoEnabled, enabled)})
+		}
+	}
+	for key := range topo.ports {
+		if _, ok := sim.ports[key]; !ok {
+			findings = append(findings, verifyFinding{"extra", "port", key, ""})
+		}
+	}
+
+	for key, status := range sim.links {
+		if topoStatus, ok := topo.links[key]; !ok {
+			findings = append(findings, verifyFinding{"missing", "link", key, status})
+		} else if topoStatus != "" && topoStatus != status {
+			findings = append(findings, verifyFinding{"mismatch", "link", key, fmt.Sprintf("status %s; expected %s", topoStatus, status)})
+		}
+	}
+	for key, status := range topo.links {
+		if _, ok := sim.links[key]; !ok {
+			findings = append(findings, verifyFinding{"extra", "link", key, status})
+		}
+	}
+
+	order := map[string]int{"device": 0, "port": 1, "link": 2}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].entity != findings[j].entity {
+			return order[findings[i].entity] < order[findings[j].entity]
+		}
+		if findings[i].id != findings[j].id {
+			return findings[i].id < findings[j].id
+		}
+		return findings[i].result < findings[j].result
+	})
+	return findings
+}
+
+func runVerifyTopoCommand(cmd *cobra.Command, _ []string) error {
+	topoAddress, _ := cmd.Flags().GetString("topo-address")
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	devices, links, _, err := getLiveEntities(conn)
+	if err != nil {
+		return err
+	}
+	objects, err := utils.QueryTopoObjects(cmd, topoAddress)
+	if err != nil {
+		return err
+	}
+
+	sim := simFabricView(devices, links)
+	findings := compareFabricViews(sim, topoFabricView(objects))
+	if len(findings) > 0 {
+		if !noHeaders {
+			cli.Output("%-9s %-7s %-40s %s\n", "Result", "Entity", "ID", "Detail")
+		}
+		for _, f := range findings {
+			cli.Output("%-9s %-7s %-40s %s\n", f.result, f.entity, f.id, f.detail)
+		}
+	}
+	cli.Output("Verified %d devices, %d ports and %d links: %d difference(s)\n",
+		len(sim.devices), len(sim.ports), len(sim.links), len(findings))
+	if len(findings) > 0 {
+		return fmt.Errorf("onos-topo differs from the simulated fabric in %d place(s)", len(findings))
+	}
+	return nil
+}
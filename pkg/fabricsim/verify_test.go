@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fabricsim
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	simapi "github.com/onosproject/onos-api/go/onos/fabricsim"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/stretchr/testify/assert"
+)
+
+func withAspect(t *testing.T, object *topoapi.Object, aspect proto.Message) *topoapi.Object {
+	object, err := object.WithAspects(aspect)
+	assert.NoError(t, err)
+	return object
+}
+
+func Test_CompareFabricViews(t *testing.T) {
+	devices := []*simapi.Device{
+		{ID: "spine1", Ports: []*simapi.Port{{ID: "spine1/1", Number: 1, Enabled: true}, {ID: "spine1/2", Number: 2, Enabled: true}}},
+		{ID: "leaf1", Ports: []*simapi.Port{{ID: "leaf1/1", Number: 1, Enabled: true}, {ID: "leaf1/2", Number: 2}}},
+		{ID: "ipu1", Type: simapi.DeviceType_IPU},
+	}
+	links := []*simapi.Link{
+		{ID: "leaf1/1-spine1/1", SrcID: "leaf1/1", TgtID: "spine1/1", Status: simapi.LinkStatus_LINK_UP},
+		{ID: "spine1/1-leaf1/1", SrcID: "spine1/1", TgtID: "leaf1/1", Status: simapi.LinkStatus_LINK_UP},
+	}
+
+	objects := []*topoapi.Object{
+		topoapi.NewEntity("spine1", topoapi.SwitchKind),
+		topoapi.NewEntity("leaf1", topoapi.SwitchKind),
+		topoapi.NewEntity("ipu1", topoapi.SwitchKind),
+		topoapi.NewEntity("leaf9", topoapi.SwitchKind),
+		withAspect(t, topoapi.NewEntity("p1", topoapi.PortKind), &topoapi.Port{Number: 1, Enabled: true}),
+		withAspect(t, topoapi.NewEntity("p2", topoapi.PortKind), &topoapi.Port{Number: 2, Enabled: true}),
+		withAspect(t, topoapi.NewEntity("p3", topoapi.PortKind), &topoapi.Port{Number: 1, Enabled: true}),
+		withAspect(t, topoapi.NewEntity("p4", topoapi.PortKind), &topoapi.Port{Number: 2, Enabled: true}),
+		topoapi.NewRelation("spine1", "p1", topoapi.CONTAINS),
+		topoapi.NewRelation("spine1", "p2", topoapi.CONTAINS),
+		topoapi.NewRelation("leaf1", "p3", topoapi.CONTAINS),
+		topoapi.NewRelation("leaf1", "p4", topoapi.CONTAINS),
+		withAspect(t, topoapi.NewRelation("p3", "p1", topoapi.LinkKind), &topoapi.Link{Status: "LINK_DOWN"}),
+		topoapi.NewRelation("p2", "p4", topoapi.LinkKind),
+	}
+
+	findings := compareFabricViews(simFabricView(devices, links), topoFabricView(objects))
+	assert.Equal(t, []verifyFinding{
+		{"mismatch", "device", "ipu1", "kind switch; expected ipu"},
+		{"extra", "device", "leaf9", "switch"},
+		{"mismatch", "port", "leaf1/2", "enabled true; expected false"},
+		{"mismatch", "link", "leaf1/1->spine1/1", "status down; expected up"},
+		{"missing", "link", "spine1/1->leaf1/1", "up"},
+		{"extra", "link", "spine1/2->leaf1/2", ""},
+	}, findings)
+
+	assert.Empty(t, compareFabricViews(simFabricView(devices, links), simFabricView(devices, links)))
+}
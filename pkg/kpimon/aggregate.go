@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpimon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statMin  = "min"
+	statMax  = "max"
+	statMean = "mean"
+	statP95  = "p95"
+)
+
+var alertRuleRegexp = regexp.MustCompile(`^\s*(?:(min|max|mean|p95)\(\s*([^\s()]+)\s*\)|([^\s()<>=!]+))\s*(<=|>=|==|!=|<|>)\s*(\S+)\s*$`)
+
+// alertRule is a threshold expression such as 'RRC.ConnEstabSucc < 10' or 'p95(RRC.ConnEstabSucc) >= 100';
+// rules without a statistic apply to every reported value, the others to the windowed statistic of a measurement
+type alertRule struct {
+	expr        string
+	stat        string
+	measurement string
+	op          string
+	threshold   float64
+}
+
+func parseAlertRule(expr string) (*alertRule, error) {
+	m := alertRuleRegexp.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, errors.NewInvalid("invalid alert expression '%s'; expected '[stat(]<measurement>[)] <op> <value>'", expr)
+	}
+	threshold, err := strconv.ParseFloat(m[5], 64)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid threshold in alert expression '%s': %v", expr, err)
+	}
+	rule := &alertRule{expr: strings.TrimSpace(expr), stat: m[1], measurement: m[2], op: m[4], threshold: threshold}
+	if rule.stat == "" {
+		rule.measurement = m[3]
+	}
+	return rule, nil
+}
+
+// trips returns true if the given value violates the rule's threshold
+func (r *alertRule) trips(value float64) bool {
+	switch r.op {
+	case "<":
+		return value < r.threshold
+	case "<=":
+		return value <= r.threshold
+	case ">":
+		return value > r.threshold
+	case ">=":
+		return value >= r.threshold
+	case "==":
+		return value == r.threshold
+	case "!=":
+		return value != r.threshold
+	}
+	return false
+}
+
+// windowStats holds the statistics of the values reported for a measurement within one window
+type windowStats struct {
+	count int
+	min   float64
+	max   float64
+	mean  float64
+	p95   float64
+}
+
+func computeStats(values []float64) windowStats {
+	if len(values) == 0 {
+		return windowStats{}
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return windowStats{
+		count: len(sorted),
+		min:   sorted[0],
+		max:   sorted[len(sorted)-1],
+		mean:  sum / float64(len(sorted)),
+		p95:   sorted[rank],
+	}
+}
+
+func (s windowStats) get(stat string) float64 {
+	switch stat {
+	case statMin:
+		return s.min
+	case statMax:
+		return s.max
+	case statMean:
+		return s.mean
+	case statP95:
+		return s.p95
+	}
+	return math.NaN()
+}
+
+// aggregator accumulates measurement values per cell for the current window
+type aggregator struct {
+	samples map[string]map[string][]float64
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{samples: make(map[string]map[string][]float64)}
+}
+
+func (a *aggregator) add(key string, measName string, value float64) {
+	if _, ok := a.samples[key]; !ok {
+		a.samples[key] = make(map[string][]float64)
+	}
+	a.samples[key][measName] = append(a.samples[key][measName], value)
+}
+
+// flush returns the statistics of the current window, keyed by cell and measurement, and starts a new window
+func (a *aggregator) flush() map[string]map[string]windowStats {
+	stats := make(map[string]map[string]windowStats, len(a.samples))
+	for key, measurements := range a.samples {
+		stats[key] = make(map[string]windowStats, len(measurements))
+		for measName, values := range measurements {
+			stats[key][measName] = computeStats(values)
+		}
+	}
+	a.samples = make(map[string]map[string][]float64)
+	return stats
+}
+
+func addAggregationFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("agg", 0, "aggregate measurements per cell over windows of the given duration")
+	cmd.Flags().StringArray("alert", []string{}, "alert expression, e.g. 'RRC.ConnEstabSucc < 10' or 'mean(RRC.ConnEstabSucc) < 10'")
+	cmd.Flags().Bool("once", false, "stop after the first report or window; exit non-zero if any alert tripped")
+}
+
+func aggregationRequested(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("agg") || cmd.Flags().Changed("alert") || cmd.Flags().Changed("once")
+}
+
+func parseAlertRules(cmd *cobra.Command, window time.Duration) ([]*alertRule, error) {
+	if window < 0 {
+		return nil, errors.NewInvalid("--agg must not be negative")
+	}
+	exprs, _ := cmd.Flags().GetStringArray("alert")
+	rules := make([]*alertRule, 0, len(exprs))
+	for _, expr := range exprs {
+		rule, err := parseAlertRule(expr)
+		if err != nil {
+			return nil, err
+		}
+		if rule.stat != "" && window == 0 {
+			return nil, errors.NewInvalid("alert expression '%s' requires --agg", expr)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func splitMeasurementKey(key string) (string, string, string) {
+	ids := strings.Split(key, ":")
+	if len(ids) != 4 {
+		return key, "", ""
+	}
+	return fmt.Sprintf("%s:%s", ids[0], ids[1]), ids[2], ids[3]
+}
+
+func outputAlert(key string, rule *alertRule, value float64) {
+	node, cellID, cellGlobalID := splitMeasurementKey(key)
+	cli.Output("%s ALERT %-10s %20s %20s %s (value %g)\n",
+		time.Now().Format("15:04:05.000"), node, cellID, cellGlobalID, rule.expr, value)
+}
+
+func outputWindowHeader(noHeaders bool) {
+	if !noHeaders {
+		cli.Output("%-12s %-10s %20s %20s %-32s %6s %12s %12s %12s %12s\n", timeHeader, nodeIDHeader, cellObjIDHeader,
+			cellGlobalIDHeader, "Measurement", "Count", "Min", "Max", "Mean", "P95")
+	}
+}
+
+// outputWindow prints the window statistics and returns the number of alerts tripped by them
+func outputWindow(stats map[string]map[string]windowStats, rules []*alertRule) int {
+	tripped := 0
+	ts := time.Now().Format("15:04:05.000")
+
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node, cellID, cellGlobalID := splitMeasurementKey(key)
+		measNames := make([]string, 0, len(stats[key]))
+		for measName := range stats[key] {
+			measNames = append(measNames, measName)
+		}
+		sort.Strings(measNames)
+
+		for _, measName := range measNames {
+			s := stats[key][measName]
+			cli.Output("%-12s %-10s %20s %20s %-32s %6d %12g %12g %12g %12g\n",
+				ts, node, cellID, cellGlobalID, measName, s.count, s.min, s.max, s.mean, s.p95)
+		}
+		for _, measName := range measNames {
+			for _, rule := range rules {
+				if rule.stat != "" && rule.measurement == measName && rule.trips(stats[key][measName].get(rule.stat)) {
+					outputAlert(key, rule, stats[key][measName].get(rule.stat))
+					tripped++
+				}
+			}
+		}
+	}
+	return tripped
+}
+
+func runWatchAggregatedMetrics(cmd *cobra.Command, client kpimonapi.KpimonClient) error {
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+	window, _ := cmd.Flags().GetDuration("agg")
+	once, _ := cmd.Flags().GetBool("once")
+	rules, err := parseAlertRules(cmd, window)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.WatchMeasurements(context.Background(), &kpimonapi.GetRequest{})
+	if err != nil {
+		return err
+	}
+
+	// Receive on a separate go-routine so that windows can be closed on time
+	done := make(chan struct{})
+	defer close(done)
+	respCh := make(chan *kpimonapi.GetResponse)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case respCh <- resp:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var tick <-chan time.Time
+	if window > 0 {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		tick = ticker.C
+		outputWindowHeader(noHeaders)
+	}
+
+	agg := newAggregator()
+	tripped := 0
+	result := func() error {
+		if once && tripped > 0 {
+			return fmt.Errorf("%d alert(s) tripped", tripped)
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case resp := <-respCh:
+			for key, measItems := range resp.GetMeasurements() {
+				for _, measItem := range measItems.MeasurementItems {
+					for _, measRecord := range measItem.MeasurementRecords {
+						value, ok := utils.MeasurementValue(measRecord.MeasurementValue)
+						if !ok {
+							continue
+						}
+						if window > 0 {
+							agg.add(key, measRecord.MeasurementName, value)
+						}
+						for _, rule := range rules {
+							if rule.stat == "" && rule.measurement == measRecord.MeasurementName && rule.trips(value) {
+								outputAlert(key, rule, value)
+								tripped++
+							}
+						}
+					}
+				}
+			}
+			if once && window == 0 {
+				return result()
+			}
+
+		case <-tick:
+			tripped += outputWindow(agg.flush(), rules)
+			if once {
+				return result()
+			}
+
+		case err := <-errCh:
+			if err == io.EOF {
+				if window > 0 {
+					tripped += outputWindow(agg.flush(), rules)
+				}
+				return result()
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpimon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseAlertRule(t *testing.T) {
+	rule, err := parseAlertRule("RRC.ConnEstabSucc < 10")
+	assert.NoError(t, err)
+	assert.Equal(t, "", rule.stat)
+	assert.Equal(t, "RRC.ConnEstabSucc", rule.measurement)
+	assert.True(t, rule.trips(9))
+	assert.False(t, rule.trips(10))
+
+	rule, err = parseAlertRule("p95(DRB.UEThpDl)>=1e3")
+	assert.NoError(t, err)
+	assert.Equal(t, statP95, rule.stat)
+	assert.Equal(t, "DRB.UEThpDl", rule.measurement)
+	assert.True(t, rule.trips(1000))
+
+	_, err = parseAlertRule("RRC.ConnEstabSucc ~ 10")
+	assert.Error(t, err)
+	_, err = parseAlertRule("avg(RRC.ConnEstabSucc) < 10")
+	assert.Error(t, err)
+	_, err = parseAlertRule("RRC.ConnEstabSucc < ten")
+	assert.Error(t, err)
+}
+
+func Test_Aggregator(t *testing.T) {
+	agg := newAggregator()
+	for i := 1; i <= 20; i++ {
+		agg.add("1:e0000:1:13842601454c001", "RRC.ConnEstabSucc", float64(i))
+	}
+	agg.add("1:e0000:2:13842601454c002", "RRC.ConnEstabSucc", 3)
+
+	stats := agg.flush()
+	assert.Len(t, stats, 2)
+	s := stats["1:e0000:1:13842601454c001"]["RRC.ConnEstabSucc"]
+	assert.Equal(t, 20, s.count)
+	assert.Equal(t, 1.0, s.get(statMin))
+	assert.Equal(t, 20.0, s.get(statMax))
+	assert.Equal(t, 10.5, s.get(statMean))
+	assert.Equal(t, 19.0, s.get(statP95))
+
+	s = stats["1:e0000:2:13842601454c002"]["RRC.ConnEstabSucc"]
+	assert.Equal(t, 3.0, s.get(statP95))
+
+	assert.Len(t, agg.flush(), 0)
+}
+
+func Test_ParseAlertRules(t *testing.T) {
+	cmd := &cobra.Command{}
+	addAggregationFlags(cmd)
+	assert.NoError(t, cmd.Flags().Set("alert", "max(RRC.ConnEstabSucc) > 10"))
+
+	rules, err := parseAlertRules(cmd, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	_, err = parseAlertRules(cmd, 0)
+	assert.Error(t, err)
+	_, err = parseAlertRules(cmd, -time.Minute)
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpimon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	metricsapi "github.com/onosproject/onos-api/go/onos/ransim/metrics"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	kpimonMetricPrefix = "kpimon_"
+	ransimMetricPrefix = "ransim_"
+	metricsPath        = "/metrics"
+)
+
+func getExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export KPIMON metrics as Prometheus gauges",
+		Args:  cobra.NoArgs,
+		RunE:  runExportCommand,
+	}
+	cmd.Flags().String("listen", ":9100", "address on which to serve the "+metricsPath+" endpoint")
+	cmd.Flags().String("ransim-address", "", "RAN simulator address; if given, its metrics are exported as well")
+	return cmd
+}
+
+// gaugeStore keeps the latest value of each gauge, keyed by metric name and by label signature
+type gaugeStore struct {
+	lock   sync.RWMutex
+	gauges map[string]map[model.Fingerprint]*model.Sample
+}
+
+func newGaugeStore() *gaugeStore {
+	return &gaugeStore{gauges: make(map[string]map[model.Fingerprint]*model.Sample)}
+}
+
+// set records the latest value of the gauge identified by the given metric
+func (s *gaugeStore) set(metric model.Metric, value float64) {
+	name := string(metric[model.MetricNameLabel])
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	samples, ok := s.gauges[name]
+	if !ok {
+		samples = make(map[model.Fingerprint]*model.Sample)
+		s.gauges[name] = samples
+	}
+	samples[metric.Fingerprint()] = &model.Sample{Metric: metric, Value: model.SampleValue(value)}
+}
+
+// remove drops the gauge identified by the given metric
+func (s *gaugeStore) remove(metric model.Metric) {
+	name := string(metric[model.MetricNameLabel])
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if samples, ok := s.gauges[name]; ok {
+		delete(samples, metric.Fingerprint())
+		if len(samples) == 0 {
+			delete(s.gauges, name)
+		}
+	}
+}
+
+// write renders all gauges using the Prometheus text exposition format
+func (s *gaugeStore) write(w io.Writer) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	names := make([]string, 0, len(s.gauges))
+	for name := range s.gauges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		lines := make([]string, 0, len(s.gauges[name]))
+		for _, sample := range s.gauges[name] {
+			lines = append(lines, fmt.Sprintf("%s %s", sample.Metric, sample.Value))
+		}
+		sort.Strings(lines)
+		if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n%s\n", name, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *gaugeStore) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = s.write(w)
+}
+
+// toMetricName turns a measurement or metric name into a valid Prometheus metric name
+func toMetricName(prefix string, name string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+	return prefix + mapped
+}
+
+// kpimonMetric returns the metric for the given measurement of the node and cell identified by key
+func kpimonMetric(key string, measName string) model.Metric {
+	metric := model.Metric{model.MetricNameLabel: model.LabelValue(toMetricName(kpimonMetricPrefix, measName))}
+	ids := strings.Split(key, ":")
+	if len(ids) == 4 {
+		metric["node_id"] = model.LabelValue(fmt.Sprintf("%s:%s", ids[0], ids[1]))
+		metric["cell_object_id"] = model.LabelValue(ids[2])
+		metric["cell_global_id"] = model.LabelValue(ids[3])
+	} else {
+		metric["key"] = model.LabelValue(key)
+	}
+	return metric
+}
+
+// ransimMetric returns the metric for the given RAN simulator metric
+func ransimMetric(m *metricsapi.Metric) model.Metric {
+	return model.Metric{
+		model.MetricNameLabel: model.LabelValue(toMetricName(ransimMetricPrefix, m.Key)),
+		"entity_id":           model.LabelValue(fmt.Sprintf("%x", m.EntityID)),
+	}
+}
+
+// ransimMetricValue parses the string value of a RAN simulator metric into a gauge value
+func ransimMetricValue(m *metricsapi.Metric) (float64, bool) {
+	if b, err := strconv.ParseBool(m.Value); err == nil && strings.HasPrefix(m.Type, "bool") {
+		if b {
+			return 1, true
+		}
+		return 0, true
+	}
+	f, err := strconv.ParseFloat(m.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func runExportCommand(cmd *cobra.Command, _ []string) error {
+	listen, _ := cmd.Flags().GetString("listen")
+	ransimAddress, _ := cmd.Flags().GetString("ransim-address")
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	store := newGaugeStore()
+	errCh := make(chan error, 3)
+
+	stream, err := kpimonapi.NewKpimonClient(conn).WatchMeasurements(context.Background(), &kpimonapi.GetRequest{})
+	if err != nil {
+		return err
+	}
+	go func() {
+		errCh <- exportMeasurements(stream, store)
+	}()
+
+	if ransimAddress != "" {
+		ransimConn, err := utils.GetServiceConnection(cmd, ransimAddress)
+		if err != nil {
+			return err
+		}
+		defer ransimConn.Close()
+
+		metricsStream, err := metricsapi.NewMetricsServiceClient(ransimConn).Watch(context.Background(), &metricsapi.WatchRequest{})
+		if err != nil {
+			return err
+		}
+		go func() {
+			errCh <- exportRansimMetrics(metricsStream, store)
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, store)
+	go func() {
+		errCh <- http.ListenAndServe(listen, mux)
+	}()
+
+	cli.Output("Serving metrics on %s%s\n", listen, metricsPath)
+	return <-errCh
+}
+
+func exportMeasurements(stream kpimonapi.Kpimon_WatchMeasurementsClient, store *gaugeStore) error {
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return errors.NewUnavailable("KPIMON measurement stream closed")
+		}
+		if err != nil {
+			return err
+		}
+		for key, measItems := range resp.GetMeasurements() {
+			for _, measItem := range measItems.MeasurementItems {
+				for _, measRecord := range measItem.MeasurementRecords {
+					if value, ok := utils.MeasurementValue(measRecord.MeasurementValue); ok {
+						store.set(kpimonMetric(key, measRecord.MeasurementName), value)
+					}
+				}
+			}
+		}
+	}
+}
+
+func exportRansimMetrics(stream metricsapi.MetricsService_WatchClient, store *gaugeStore) error {
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return errors.NewUnavailable("RAN simulator metrics stream closed")
+		}
+		if err != nil {
+			return err
+		}
+		if resp.Type == metricsapi.EventType_DELETED {
+			store.remove(ransimMetric(resp.Metric))
+		} else if value, ok := ransimMetricValue(resp.Metric); ok {
+			store.set(ransimMetric(resp.Metric), value)
+		}
+	}
+}
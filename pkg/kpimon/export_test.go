@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpimon
+
+import (
+	"bytes"
+	"testing"
+
+	prototypes "github.com/gogo/protobuf/types"
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	metricsapi "github.com/onosproject/onos-api/go/onos/ransim/metrics"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GaugeStore(t *testing.T) {
+	store := newGaugeStore()
+	store.set(kpimonMetric("1:e0000:1:13842601454c001", "RRC.ConnEstabSucc"), 12)
+	store.set(kpimonMetric("1:e0000:2:13842601454c002", "RRC.ConnEstabSucc"), 7)
+	store.set(kpimonMetric("1:e0000:1:13842601454c001", "RRC.ConnEstabSucc"), 15)
+
+	m := &metricsapi.Metric{EntityID: 0x13842601454c001, Key: "tx-power", Value: "11.5", Type: "float64"}
+	value, ok := ransimMetricValue(m)
+	assert.True(t, ok)
+	store.set(ransimMetric(m), value)
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, store.write(buffer))
+	assert.Equal(t, `# TYPE kpimon_RRC_ConnEstabSucc gauge
+kpimon_RRC_ConnEstabSucc{cell_global_id="13842601454c001", cell_object_id="1", node_id="1:e0000"} 15
+kpimon_RRC_ConnEstabSucc{cell_global_id="13842601454c002", cell_object_id="2", node_id="1:e0000"} 7
+# TYPE ransim_tx_power gauge
+ransim_tx_power{entity_id="13842601454c001"} 11.5
+`, buffer.String())
+
+	store.remove(ransimMetric(m))
+	buffer.Reset()
+	assert.NoError(t, store.write(buffer))
+	assert.NotContains(t, buffer.String(), "ransim_tx_power")
+}
+
+func Test_RansimMetricValue(t *testing.T) {
+	value, ok := ransimMetricValue(&metricsapi.Metric{Value: "true", Type: "bool"})
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, value)
+
+	_, ok = ransimMetricValue(&metricsapi.Metric{Value: "blue", Type: "string"})
+	assert.False(t, ok)
+}
+
+func Test_MeasurementValue(t *testing.T) {
+	intValue, err := prototypes.MarshalAny(&kpimonapi.IntegerValue{Value: 12})
+	assert.NoError(t, err)
+	value, ok := utils.MeasurementValue(intValue)
+	assert.True(t, ok)
+	assert.Equal(t, 12.0, value)
+
+	noValue, err := prototypes.MarshalAny(&kpimonapi.NoValue{})
+	assert.NoError(t, err)
+	_, ok = utils.MeasurementValue(noValue)
+	assert.False(t, ok)
+}
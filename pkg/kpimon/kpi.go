@@ -17,6 +17,7 @@ import (
 	"github.com/prometheus/common/log"
 
 	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	"github.com/onosproject/onos-cli/pkg/utils"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
 	"github.com/spf13/cobra"
 )
@@ -45,9 +46,19 @@ func getWatchMetricsCommand() *cobra.Command {
 		RunE:  runWatchMetricsCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
+	addAggregationFlags(cmd)
 	return cmd
 }
 
+// decodeMeasurementValue unpacks the value carried by a measurement record
+func decodeMeasurementValue(measValue *prototypes.Any) interface{} {
+	value, err := utils.DecodeMeasurementValue(measValue)
+	if err != nil {
+		log.Warn(err)
+	}
+	return value
+}
+
 func runListMetricsCommand(cmd *cobra.Command, _ []string) error {
 	var types []string
 	results := make(map[string]map[uint64]map[string]string)
@@ -89,34 +100,7 @@ func runListMetricsCommand(cmd *cobra.Command, _ []string) error {
 					results[key][timeStamp] = make(map[string]string)
 				}
 
-				var value interface{}
-
-				switch {
-				case prototypes.Is(measValue, &kpimonapi.IntegerValue{}):
-					v := kpimonapi.IntegerValue{}
-					err := prototypes.UnmarshalAny(measValue, &v)
-					if err != nil {
-						log.Warn(err)
-					}
-					value = v.GetValue()
-
-				case prototypes.Is(measValue, &kpimonapi.RealValue{}):
-					v := kpimonapi.RealValue{}
-					err := prototypes.UnmarshalAny(measValue, &v)
-					if err != nil {
-						log.Warn(err)
-					}
-					value = v.GetValue()
-
-				case prototypes.Is(measValue, &kpimonapi.NoValue{}):
-					v := kpimonapi.NoValue{}
-					err := prototypes.UnmarshalAny(measValue, &v)
-					if err != nil {
-						log.Warn(err)
-					}
-					value = v.GetValue()
-
-				}
+				value := decodeMeasurementValue(measValue)
 
 				results[key][timeStamp][measName] = fmt.Sprintf("%v", value)
 			}
@@ -201,6 +185,10 @@ func runWatchMetricsCommand(cmd *cobra.Command, _ []string) error {
 	request := kpimonapi.GetRequest{}
 	client := kpimonapi.NewKpimonClient(conn)
 
+	if aggregationRequested(cmd) {
+		return runWatchAggregatedMetrics(cmd, client)
+	}
+
 	respWatchMeasurement, err := client.WatchMeasurements(context.Background(), &request)
 	if err != nil {
 		return err
@@ -237,34 +225,7 @@ func runWatchMetricsCommand(cmd *cobra.Command, _ []string) error {
 						results[key][timeStamp] = make(map[string]string)
 					}
 
-					var value interface{}
-
-					switch {
-					case prototypes.Is(measValue, &kpimonapi.IntegerValue{}):
-						v := kpimonapi.IntegerValue{}
-						err := prototypes.UnmarshalAny(measValue, &v)
-						if err != nil {
-							log.Warn(err)
-						}
-						value = v.GetValue()
-
-					case prototypes.Is(measValue, &kpimonapi.RealValue{}):
-						v := kpimonapi.RealValue{}
-						err := prototypes.UnmarshalAny(measValue, &v)
-						if err != nil {
-							log.Warn(err)
-						}
-						value = v.GetValue()
-
-					case prototypes.Is(measValue, &kpimonapi.NoValue{}):
-						v := kpimonapi.NoValue{}
-						err := prototypes.UnmarshalAny(measValue, &v)
-						if err != nil {
-							log.Warn(err)
-						}
-						value = v.GetValue()
-
-					}
+					value := decodeMeasurementValue(measValue)
 
 					results[key][timeStamp][measName] = fmt.Sprintf("%v", value)
 				}
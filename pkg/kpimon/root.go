@@ -36,6 +36,7 @@ func GetCommand() *cobra.Command {
 	cmd.AddCommand(getListCommand())
 	cmd.AddCommand(getWatchCommand())
 	cmd.AddCommand(getSetCommand())
+	cmd.AddCommand(getExportCommand())
 	cmd.AddCommand(loglib.GetCommand())
 	return cmd
 }
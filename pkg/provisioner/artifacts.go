@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/onosproject/onos-api/go/onos/provisioner"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// expectedArtifacts lists the artifacts required by each kind of configuration
+var expectedArtifacts = map[string][]string{
+	provisioner.PipelineConfigKind: {provisioner.P4InfoType, provisioner.P4BinaryType},
+	provisioner.ChassisConfigKind:  {provisioner.ChassisType},
+}
+
+// readArtifactsDir reads the regular files of a directory into an artifact map; each artifact is named
+// after its file, without any extension, e.g. p4info.txt is read as the p4info artifact
+func readArtifactsDir(dir string) (map[string][]byte, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := make(map[string][]byte)
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := strings.SplitN(entry.Name(), ".", 2)[0]
+		if other, ok := files[name]; ok {
+			return nil, errors.NewInvalid("files %s and %s both provide artifact %s", other, entry.Name(), name)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = entry.Name()
+		artifacts[name] = data
+	}
+	return artifacts, nil
+}
+
+// artifactName returns the name of the artifact of a tarball entry as a cleaned path, so that ./p4info is named p4info
+func artifactName(entry string) string {
+	return path.Clean(entry)
+}
+
+// validateArtifacts checks that the non-empty artifacts expected for the kind of configuration are present. With
+// exact, which applies to artifacts read from a directory, no other artifacts may be present
+func validateArtifacts(kind string, artifacts map[string][]byte, exact bool) error {
+	expected, ok := expectedArtifacts[kind]
+	if !ok {
+		return errors.NewInvalid("unknown configuration kind %s; expected %s or %s",
+			kind, provisioner.PipelineConfigKind, provisioner.ChassisConfigKind)
+	}
+	known := make(map[string]bool)
+	for _, name := range expected {
+		known[name] = true
+		data, ok := artifacts[name]
+		if !ok {
+			return errors.NewInvalid("%s configuration requires the %s artifact", kind, name)
+		}
+		if len(data) == 0 {
+			return errors.NewInvalid("artifact %s is empty", name)
+		}
+	}
+	if exact {
+		for name := range artifacts {
+			if !known[name] {
+				return errors.NewInvalid("unexpected artifact %s for %s configuration; expected %s",
+					name, kind, strings.Join(expected, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// sortedArtifactNames returns the names of the artifacts in alphabetical order
+func sortedArtifactNames(artifacts map[string][]byte) []string {
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func digest(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+func printArtifacts(noHeaders bool, artifacts map[string][]byte) {
+	if !noHeaders {
+		cli.Output("%-16s\t%10s\t%s\n", "Artifact", "Size", "SHA-256")
+	}
+	for _, name := range sortedArtifactNames(artifacts) {
+		cli.Output("%-16s\t%10d\t%s\n", name, len(artifacts[name]), digest(artifacts[name]))
+	}
+}
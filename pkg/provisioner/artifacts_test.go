@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/provisioner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadArtifactsDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p4info.txt"), []byte("pkg_info {}"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p4bin"), []byte{0, 1, 2}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0644))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0755))
+
+	artifacts, err := readArtifactsDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p4bin", "p4info"}, sortedArtifactNames(artifacts))
+	assert.NoError(t, validateArtifacts(provisioner.PipelineConfigKind, artifacts, true))
+	assert.Error(t, validateArtifacts(provisioner.ChassisConfigKind, artifacts, true))
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p4bin.bin"), []byte{0, 1, 2}, 0644))
+	_, err = readArtifactsDir(dir)
+	assert.Error(t, err)
+}
+
+func TestValidateArtifacts(t *testing.T) {
+	assert.NoError(t, validateArtifacts(provisioner.ChassisConfigKind, map[string][]byte{"chassis": []byte("description: x")}, true))
+	assert.Error(t, validateArtifacts(provisioner.ChassisConfigKind, map[string][]byte{"chassis": {}}, true))
+	assert.Error(t, validateArtifacts(provisioner.PipelineConfigKind, map[string][]byte{"p4info": []byte("x")}, true))
+	assert.Error(t, validateArtifacts(provisioner.PipelineConfigKind,
+		map[string][]byte{"p4info": []byte("x"), "p4bin": []byte("y"), "p4info.txt": []byte("z")}, true))
+	assert.Error(t, validateArtifacts("gnmi", map[string][]byte{}, true))
+
+	// Tarballs may carry other entries
+	tarball := map[string][]byte{"p4info": []byte("x"), "p4bin": []byte("y"), "README": []byte("z")}
+	assert.NoError(t, validateArtifacts(provisioner.PipelineConfigKind, tarball, false))
+	assert.Error(t, validateArtifacts(provisioner.PipelineConfigKind, tarball, true))
+	assert.Error(t, validateArtifacts(provisioner.ChassisConfigKind, tarball, false))
+}
+
+func TestReadArtifactsCleanedNames(t *testing.T) {
+	tarball := filepath.Join(t.TempDir(), "artifacts.tgz")
+	assert.NoError(t, writeArtifacts(tarball, map[string][]byte{"./p4info": []byte("x"), "./p4bin": []byte("y")}))
+	artifacts, err := readArtifacts(tarball)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p4bin", "p4info"}, sortedArtifactNames(artifacts))
+	assert.NoError(t, validateArtifacts(provisioner.PipelineConfigKind, artifacts, false))
+
+	assert.NoError(t, writeArtifacts(tarball, map[string][]byte{"./p4info": []byte("x"), "p4info": []byte("y")}))
+	_, err = readArtifacts(tarball)
+	assert.Error(t, err)
+}
+
+func TestDigest(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", digest(nil))
+}
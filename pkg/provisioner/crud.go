@@ -10,6 +10,7 @@ import (
 	"context"
 	"github.com/onosproject/onos-api/go/onos/provisioner"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"io"
@@ -20,6 +21,8 @@ import (
 const (
 	kindFlag          = "kind"
 	artifactsPathFlag = "artifacts"
+	artifactsDirFlag  = "artifacts-dir"
+	showArtifactsFlag = "show-artifacts"
 	noHeadersFlag     = "no-headers"
 )
 
@@ -27,11 +30,16 @@ func getAddCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add config <config-id> [args]",
 		Short: "Add new device configuration",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runAddConfigCommand,
+		Long: `Add new device configuration with artifacts from a gzip tar file or from a directory. A pipeline
+configuration requires the p4info and p4bin artifacts, and a chassis configuration the chassis artifact.
+Artifacts read from a directory are named after their files without extension, e.g. p4info.txt, and may
+only be those required; a tar file may carry other entries as well.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAddConfigCommand,
 	}
 	cmd.Flags().String(kindFlag, provisioner.PipelineConfigKind, "kind of configuration: pipeline or chassis")
 	cmd.Flags().String(artifactsPathFlag, "-", "artifacts tar file (- for stdin)")
+	cmd.Flags().String(artifactsDirFlag, "", "directory with the artifact files")
 	return cmd
 }
 
@@ -56,6 +64,7 @@ func getGetCommand() *cobra.Command {
 	}
 	cmd.Flags().String(kindFlag, provisioner.PipelineConfigKind, "kind of configuration: pipeline or chassis")
 	cmd.Flags().String(artifactsPathFlag, "", "artifacts tar file; - for stdin")
+	cmd.Flags().Bool(showArtifactsFlag, false, "lists the artifact names, sizes and SHA-256 digests")
 	cmd.Flags().Bool(noHeadersFlag, false, "disables output headers")
 	return cmd
 }
@@ -72,11 +81,24 @@ func runAddConfigCommand(cmd *cobra.Command, args []string) error {
 	configID := provisioner.ConfigID(args[0])
 	kind, _ := cmd.Flags().GetString(kindFlag)
 	artifactsPath, _ := cmd.Flags().GetString(artifactsPathFlag)
+	artifactsDir, _ := cmd.Flags().GetString(artifactsDirFlag)
+	if artifactsDir != "" && cmd.Flags().Changed(artifactsPathFlag) {
+		return errors.NewInvalid("--%s and --%s are mutually exclusive", artifactsPathFlag, artifactsDirFlag)
+	}
 
-	artifacts, err := readArtifacts(artifactsPath)
+	var artifacts map[string][]byte
+	var err error
+	if artifactsDir != "" {
+		artifacts, err = readArtifactsDir(artifactsDir)
+	} else {
+		artifacts, err = readArtifacts(artifactsPath)
+	}
 	if err != nil {
 		return err
 	}
+	if err = validateArtifacts(kind, artifacts, artifactsDir != ""); err != nil {
+		return err
+	}
 
 	client, conn, err := getProvisionerClient(cmd)
 	if err != nil {
@@ -142,7 +164,8 @@ func listConfigs(cmd *cobra.Command) error {
 func getConfig(cmd *cobra.Command, configID provisioner.ConfigID) error {
 	noHeaders, _ := cmd.Flags().GetBool(noHeadersFlag)
 	artifactsPath, _ := cmd.Flags().GetString(artifactsPathFlag)
-	includeArtifacts := len(artifactsPath) > 0
+	showArtifacts, _ := cmd.Flags().GetBool(showArtifactsFlag)
+	includeArtifacts := len(artifactsPath) > 0 || showArtifacts
 
 	client, conn, err := getProvisionerClient(cmd)
 	if err != nil {
@@ -155,9 +178,13 @@ func getConfig(cmd *cobra.Command, configID provisioner.ConfigID) error {
 	if err != nil {
 		return err
 	}
-	if includeArtifacts {
+	if len(artifactsPath) > 0 {
 		return writeArtifacts(artifactsPath, resp.Config.Artifacts)
 	}
+	if showArtifacts {
+		printArtifacts(noHeaders, resp.Config.Artifacts)
+		return nil
+	}
 
 	printConfigHeaders(noHeaders)
 	printConfigRecord(resp.Config.Record)
@@ -175,7 +202,7 @@ func printConfigRecord(record *provisioner.ConfigRecord) {
 	cli.Output("%-32s\t%-12s\t%s\n", record.ConfigID, record.Kind, artifacts)
 }
 
-// Reads artifacts from the given gzip tar archive (stdin if "-") into an artifact map
+// Reads artifacts from the given gzip tar archive (stdin if "-") into an artifact map, named by their cleaned paths
 func readArtifacts(path string) (map[string][]byte, error) {
 	var input io.Reader
 	var err error
@@ -203,9 +230,13 @@ func readArtifacts(path string) (map[string][]byte, error) {
 			return artifacts, nil
 		case err1 != nil:
 			return nil, err1
-		case header == nil:
+		case header == nil, header.Typeflag == tar.TypeDir:
 			continue
 		}
+		name := artifactName(header.Name)
+		if _, ok := artifacts[name]; ok {
+			return nil, errors.NewInvalid("archive contains artifact %s more than once", name)
+		}
 
 		data := make([]byte, header.Size)
 		for total := 0; total < int(header.Size); {
@@ -215,7 +246,7 @@ func readArtifacts(path string) (map[string][]byte, error) {
 			}
 			total = total + l
 		}
-		artifacts[header.Name] = data
+		artifacts[name] = data
 	}
 }
 
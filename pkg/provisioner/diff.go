@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/onosproject/onos-api/go/onos/provisioner"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"unicode/utf8"
+)
+
+const contextFlag = "context"
+
+func getDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <config-id-a> <config-id-b>",
+		Short: "Show differences between two device configurations",
+		Long: `Show the artifacts added, removed and changed from the first to the second device configuration.
+Changed text artifacts, such as p4info text protos and chassis configs, are shown as a unified diff,
+and changed binary artifacts by their sizes and SHA-256 digests.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiffCommand,
+	}
+	cmd.Flags().Int(contextFlag, 3, "number of context lines of unified diffs")
+	return cmd
+}
+
+// isText returns true if the data is valid UTF-8 without NUL characters
+func isText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// diffConfigs renders the differences between the artifacts of two configurations
+func diffConfigs(a *provisioner.Config, b *provisioner.Config, context int) (string, error) {
+	out := &bytes.Buffer{}
+	if a.Record.Kind != b.Record.Kind {
+		fmt.Fprintf(out, "kind: %s -> %s\n", a.Record.Kind, b.Record.Kind)
+	}
+
+	names := sortedArtifactNames(a.Artifacts)
+	for _, name := range sortedArtifactNames(b.Artifacts) {
+		if _, ok := a.Artifacts[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	unchanged := 0
+	for _, name := range names {
+		dataA, inA := a.Artifacts[name]
+		dataB, inB := b.Artifacts[name]
+		switch {
+		case !inB:
+			fmt.Fprintf(out, "removed: %s (%d bytes, sha256 %s)\n", name, len(dataA), digest(dataA))
+		case !inA:
+			fmt.Fprintf(out, "added: %s (%d bytes, sha256 %s)\n", name, len(dataB), digest(dataB))
+		case bytes.Equal(dataA, dataB):
+			unchanged++
+		case isText(dataA) && isText(dataB):
+			fmt.Fprintf(out, "changed: %s\n", name)
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(dataA)),
+				B:        difflib.SplitLines(string(dataB)),
+				FromFile: fmt.Sprintf("%s/%s", a.Record.ConfigID, name),
+				ToFile:   fmt.Sprintf("%s/%s", b.Record.ConfigID, name),
+				Context:  context,
+			})
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(diff)
+		default:
+			fmt.Fprintf(out, "changed: %s (binary)\n\tsize: %d -> %d bytes\n\tsha256: %s -> %s\n",
+				name, len(dataA), len(dataB), digest(dataA), digest(dataB))
+		}
+	}
+	fmt.Fprintf(out, "%d artifact(s) unchanged\n", unchanged)
+	return out.String(), nil
+}
+
+func runDiffCommand(cmd *cobra.Command, args []string) error {
+	contextLines, _ := cmd.Flags().GetInt(contextFlag)
+
+	client, conn, err := getProvisionerClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	configs := make([]*provisioner.Config, 0, len(args))
+	for _, id := range args {
+		req := &provisioner.GetConfigRequest{ConfigID: provisioner.ConfigID(id), IncludeArtifacts: true}
+		resp, err := client.Get(context.Background(), req)
+		if err != nil {
+			return err
+		}
+		configs = append(configs, resp.Config)
+	}
+
+	diff, err := diffConfigs(configs[0], configs[1], contextLines)
+	if err != nil {
+		return err
+	}
+	cli.Output("%s", diff)
+	return nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/provisioner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	a := &provisioner.Config{
+		Record: &provisioner.ConfigRecord{ConfigID: "fabric-v1", Kind: provisioner.PipelineConfigKind},
+		Artifacts: map[string][]byte{
+			"p4info": []byte("tables {\n  id: 1\n}\nactions {\n  id: 2\n}\n"),
+			"p4bin":  {0, 1, 2, 3},
+			"extra":  []byte("same"),
+			"notes":  []byte("old"),
+		},
+	}
+	b := &provisioner.Config{
+		Record: &provisioner.ConfigRecord{ConfigID: "fabric-v2", Kind: provisioner.PipelineConfigKind},
+		Artifacts: map[string][]byte{
+			"p4info": []byte("tables {\n  id: 1\n}\nactions {\n  id: 3\n}\n"),
+			"p4bin":  {0, 1, 2, 3, 4},
+			"extra":  []byte("same"),
+			"readme": []byte("new"),
+		},
+	}
+
+	diff, err := diffConfigs(a, b, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, `removed: notes (3 bytes, sha256 `+digest([]byte("old"))+`)
+changed: p4bin (binary)
+	size: 4 -> 5 bytes
+	sha256: `+digest(a.Artifacts["p4bin"])+` -> `+digest(b.Artifacts["p4bin"])+`
+changed: p4info
+--- fabric-v1/p4info
++++ fabric-v2/p4info
+@@ -4,3 +4,3 @@
+ actions {
+-  id: 2
++  id: 3
+ }
+added: readme (3 bytes, sha256 `+digest([]byte("new"))+`)
+1 artifact(s) unchanged
+`, diff)
+
+	diff, err = diffConfigs(a, a, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "4 artifact(s) unchanged\n", diff)
+}
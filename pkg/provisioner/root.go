@@ -28,7 +28,7 @@ func Init() {
 // GetCommand returns the root command for the device provisioner service
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "provisioner {add, delete, get} [args]",
+		Use:     "provisioner {add, delete, get, diff} [args]",
 		Aliases: []string{"device-provisioner"},
 		Short:   "Device provisioner subsystem commands",
 	}
@@ -39,6 +39,7 @@ func GetCommand() *cobra.Command {
 	cmd.AddCommand(getAddCommand())
 	cmd.AddCommand(getDeleteCommand())
 	cmd.AddCommand(getGetCommand())
+	cmd.AddCommand(getDiffCommand())
 	cmd.AddCommand(loglib.GetCommand())
 	return cmd
 }
@@ -6,6 +6,8 @@ package ransim
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"strconv"
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
@@ -22,7 +24,7 @@ func getCellsCommand() *cobra.Command {
 		RunE:  runGetCellsCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
-	cmd.Flags().BoolP("watch", "w", false, "watch cell changes")
+	addWatchFlags(cmd, "cell")
 
 	return cmd
 }
@@ -69,6 +71,7 @@ func updateCellCommand() *cobra.Command {
 	}
 	cmd.Flags().Uint32("max-ues", 10000, "maximum number of UEs connected")
 	cmd.Flags().Float64("tx-power", 11.0, "transmit power (dB)")
+	cmd.Flags().Float64("tx-power-delta", 0, "change of the current transmit power (dB)")
 	cmd.Flags().Float64("lat", 11.0, "geo location latitude")
 	cmd.Flags().Float64("lng", 11.0, "geo location longitude")
 	cmd.Flags().Int32("azimuth", 0, "azimuth of the coverage arc")
@@ -104,11 +107,11 @@ func getCellClient(cmd *cobra.Command) (modelapi.CellModelClient, *grpc.ClientCo
 }
 
 func runGetCellsCommand(cmd *cobra.Command, _ []string) error {
-	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); !noHeaders {
+	outputGetHeader(cmd, "NCGI", func() {
 		cli.Output("%-17s %7s %7s %7s %9s %9s %7s %7s %10s %7s %7s %10s %10s %8s %8s %4s %4s %s\n",
 			"NCGI", "#UEs", "Max UEs", "TxDB", "Lat", "Lng", "Azimuth", "Arc",
 			"A3Offset", "TTT", "A3Hyst", "PCellOffset", "FreqOffset", "PCI", "Color", "Idle", "Conn", "Neighbors(NCellOffset)")
-	}
+	})
 
 	client, conn, err := getCellClient(cmd)
 	if err != nil {
@@ -121,43 +124,42 @@ func runGetCellsCommand(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return err
 		}
-
+		differ := getWatchDiffer(cmd)
 		for {
 			r, err := stream.Recv()
-			if err != nil {
-				break
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
 			}
 			cell := r.Cell
-			cli.Output("%-17x %7d %7d %7.2f %9.3f %9.3f %7d %7d %10d %7d %7d %10d %10d %8d %8s %4d %4d %s\n",
-				cell.NCGI, len(cell.CrntiMap), cell.MaxUEs, cell.TxPowerdB,
-				cell.Location.Lat, cell.Location.Lng, cell.Sector.Azimuth, cell.Sector.Arc,
-				cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.TimeToTrigger, cell.MeasurementParams.Hysteresis,
-				cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.FrequencyOffset, cell.Pci, cell.Color,
-				cell.RrcIdleCount, cell.RrcConnectedCount, catNCGIsWithOcn(cell.Neighbors, cell.MeasurementParams.NcellIndividualOffsets))
+			outputWatchEvent(differ, r.Type, fmt.Sprintf("%x", cell.NCGI), cellFields(cell), func() { outputCellLine(cell) })
 		}
+	}
 
-	} else {
+	stream, err := client.ListCells(context.Background(), &modelapi.ListCellsRequest{})
+	if err != nil {
+		return err
+	}
 
-		stream, err := client.ListCells(context.Background(), &modelapi.ListCellsRequest{})
-		if err != nil {
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
 			return err
 		}
-
-		for {
-			r, err := stream.Recv()
-			if err != nil {
-				break
-			}
-			cell := r.Cell
-			cli.Output("%-17x %7d %7d %7.2f %9.3f %9.3f %7d %7d %10d %7d %7d %10d %10d %8d %8s %4d, %4d, %s\n",
-				cell.NCGI, len(cell.CrntiMap), cell.MaxUEs, cell.TxPowerdB,
-				cell.Location.Lat, cell.Location.Lng, cell.Sector.Azimuth, cell.Sector.Arc,
-				cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.TimeToTrigger, cell.MeasurementParams.Hysteresis,
-				cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.FrequencyOffset, cell.Pci, cell.Color,
-				cell.RrcIdleCount, cell.RrcConnectedCount, catNCGIsWithOcn(cell.Neighbors, cell.MeasurementParams.NcellIndividualOffsets))
-		}
+		outputCellLine(r.Cell)
 	}
-	return nil
+}
+
+func outputCellLine(cell *types.Cell) {
+	cli.Output("%-17x %7d %7d %7.2f %9.3f %9.3f %7d %7d %10d %7d %7d %10d %10d %8d %8s %4d %4d %s\n",
+		cell.NCGI, len(cell.CrntiMap), cell.MaxUEs, cell.TxPowerdB,
+		cell.Location.Lat, cell.Location.Lng, cell.Sector.Azimuth, cell.Sector.Arc,
+		cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.TimeToTrigger, cell.MeasurementParams.Hysteresis,
+		cell.MeasurementParams.EventA3Params.A3Offset, cell.MeasurementParams.FrequencyOffset, cell.Pci, cell.Color,
+		cell.RrcIdleCount, cell.RrcConnectedCount, catNCGIsWithOcn(cell.Neighbors, cell.MeasurementParams.NcellIndividualOffsets))
 }
 
 func optionsToCell(cmd *cobra.Command, cell *types.Cell, update bool) (*types.Cell, error) {
@@ -179,6 +181,10 @@ func optionsToCell(cmd *cobra.Command, cell *types.Cell, update bool) (*types.Ce
 	if !update || cmd.Flags().Changed("tx-power") {
 		cell.TxPowerdB = txPower
 	}
+	txPowerDelta, _ := cmd.Flags().GetFloat64("tx-power-delta")
+	if update && cmd.Flags().Changed("tx-power-delta") {
+		cell.TxPowerdB += txPowerDelta
+	}
 	return cell, nil
 }
 
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	chaosStart = "start"
+	chaosStop  = "stop"
+)
+
+// chaosEvent is a node agent command issued at an offset from the start of a chaos run
+type chaosEvent struct {
+	at      time.Duration
+	command string
+	gnbID   types.GnbID
+}
+
+// String renders the event as a line of a replayable timeline
+func (e chaosEvent) String() string {
+	return fmt.Sprintf("%s %s %x", e.at, e.command, e.gnbID)
+}
+
+func chaosCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chaos",
+		Args:  cobra.NoArgs,
+		Short: "Randomly stop and start E2 node agents",
+		Long: `Randomly stop and start E2 node agents. Each node stays up for an exponentially distributed time
+with mean --mtbf, then down for an exponentially distributed time with mean --mttr, until --duration has
+passed; nodes still down by then are started again. The schedule is derived from --seed, and the
+timeline written with --timeline can be run again with --replay. Events that fail are recorded in the
+timeline along with their error, and are issued again when it is replayed.`,
+		RunE: runChaosCommand,
+	}
+	cmd.Flags().String("nodes", "all", "comma-separated gNB IDs (hex) of the nodes to disrupt, or all")
+	cmd.Flags().Duration("mtbf", 2*time.Minute, "mean time between failures of a node")
+	cmd.Flags().Duration("mttr", 20*time.Second, "mean time to repair a node")
+	cmd.Flags().Duration("duration", time.Hour, "duration of the chaos run")
+	cmd.Flags().Int64("seed", 0, "seed of the random schedule; defaults to the current time")
+	cmd.Flags().String("timeline", "", "file to which the timeline is written")
+	cmd.Flags().String("replay", "", "timeline file to replay instead of a random schedule")
+	cmd.Flags().Bool("dry-run", false, "print the timeline without stopping or starting any node")
+	return cmd
+}
+
+// chaosSchedule computes the stop and start events of the given nodes, ordered by offset
+func chaosSchedule(nodes []types.GnbID, mtbf time.Duration, mttr time.Duration, duration time.Duration, seed int64) []chaosEvent {
+	rng := rand.New(rand.NewSource(seed))
+	events := make([]chaosEvent, 0)
+	for _, gnbID := range nodes {
+		at := time.Duration(0)
+		for {
+			at += time.Duration(rng.ExpFloat64() * float64(mtbf)).Round(time.Millisecond)
+			if at >= duration {
+				break
+			}
+			events = append(events, chaosEvent{at: at, command: chaosStop, gnbID: gnbID})
+			at += time.Duration(rng.ExpFloat64() * float64(mttr)).Round(time.Millisecond)
+			if at >= duration {
+				at = duration
+			}
+			events = append(events, chaosEvent{at: at, command: chaosStart, gnbID: gnbID})
+		}
+	}
+	sortChaosEvents(events)
+	return events
+}
+
+func sortChaosEvents(events []chaosEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].at != events[j].at {
+			return events[i].at < events[j].at
+		}
+		return events[i].gnbID < events[j].gnbID
+	})
+}
+
+// readTimeline parses a timeline written by a previous chaos run
+func readTimeline(r io.Reader) ([]chaosEvent, error) {
+	events := make([]chaosEvent, 0)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		// Comments take up whole lines or, like the errors of failed events, trail an entry
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 || (fields[1] != chaosStart && fields[1] != chaosStop) {
+			return nil, errors.NewInvalid("invalid timeline entry at line %d: %s", line, text)
+		}
+		at, err := time.ParseDuration(fields[0])
+		if err != nil {
+			return nil, errors.NewInvalid("invalid offset at line %d: %v", line, err)
+		}
+		gnbID, err := strconv.ParseUint(fields[2], 16, 64)
+		if err != nil {
+			return nil, errors.NewInvalid("invalid gNB ID at line %d: %v", line, err)
+		}
+		events = append(events, chaosEvent{at: at, command: fields[1], gnbID: types.GnbID(gnbID)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sortChaosEvents(events)
+	return events, nil
+}
+
+// getChaosNodes resolves the --nodes selection into a sorted list of gNB IDs
+func getChaosNodes(cmd *cobra.Command, client modelapi.NodeModelClient) ([]types.GnbID, error) {
+	selection, _ := cmd.Flags().GetString("nodes")
+	nodes := make([]types.GnbID, 0)
+	if selection == "all" {
+		stream, err := client.ListNodes(context.Background(), &modelapi.ListNodesRequest{})
+		if err != nil {
+			return nil, err
+		}
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, r.Node.GnbID)
+		}
+	} else {
+		for _, id := range strings.Split(selection, ",") {
+			gnbID, err := strconv.ParseUint(strings.TrimSpace(id), 16, 64)
+			if err != nil {
+				return nil, errors.NewInvalid("invalid gNB ID %s", id)
+			}
+			nodes = append(nodes, types.GnbID(gnbID))
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, errors.NewNotFound("no nodes to disrupt")
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	return nodes, nil
+}
+
+func runChaosCommand(cmd *cobra.Command, _ []string) error {
+	mtbf, _ := cmd.Flags().GetDuration("mtbf")
+	mttr, _ := cmd.Flags().GetDuration("mttr")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	replay, _ := cmd.Flags().GetString("replay")
+	timelinePath, _ := cmd.Flags().GetString("timeline")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if mtbf <= 0 || mttr <= 0 {
+		return errors.NewInvalid("--mtbf and --mttr must be positive")
+	}
+
+	client, conn, err := getNodeClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var events []chaosEvent
+	var header string
+	if replay != "" {
+		f, err := os.Open(replay)
+		if err != nil {
+			return err
+		}
+		events, err = readTimeline(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		header = fmt.Sprintf("# replay of %s", replay)
+	} else {
+		nodes, err := getChaosNodes(cmd, client)
+		if err != nil {
+			return err
+		}
+		events = chaosSchedule(nodes, mtbf, mttr, duration, seed)
+		header = fmt.Sprintf("# seed %d mtbf %s mttr %s duration %s nodes %d", seed, mtbf, mttr, duration, len(nodes))
+	}
+
+	var timeline io.Writer = io.Discard
+	if timelinePath != "" {
+		f, err := os.Create(timelinePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		timeline = f
+	}
+	if _, err = fmt.Fprintln(timeline, header); err != nil {
+		return err
+	}
+	cli.Output("%s\n", header)
+
+	if dryRun {
+		for _, e := range events {
+			cli.Output("%s\n", e)
+			if _, err = fmt.Fprintln(timeline, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	stopped := make(map[types.GnbID]bool)
+	failures := 0
+	start := time.Now()
+	for _, e := range events {
+		select {
+		case <-time.After(time.Until(start.Add(e.at))):
+		case <-interrupt:
+			cli.Output("Interrupted; starting %d stopped node(s)\n", len(stopped))
+			for gnbID := range stopped {
+				if _, err := controlNode(client, chaosStart, gnbID); err != nil {
+					cli.Output("Unable to start node %x: %v\n", gnbID, err)
+				}
+			}
+			return errors.NewCanceled("chaos run interrupted")
+		}
+
+		if _, err := controlNode(client, e.command, e.gnbID); err != nil {
+			failures++
+			cli.Output("%s %s failed: %v\n", time.Now().Format("15:04:05.000"), e, err)
+			// Failed events are replayed too, so that a replay issues the same commands as this run
+			reason := strings.Join(strings.Fields(err.Error()), " ")
+			if _, err = fmt.Fprintf(timeline, "%s # failed: %s\n", e, reason); err != nil {
+				return err
+			}
+			continue
+		}
+		if e.command == chaosStop {
+			stopped[e.gnbID] = true
+		} else {
+			delete(stopped, e.gnbID)
+		}
+		cli.Output("%s %s\n", time.Now().Format("15:04:05.000"), e)
+		if _, err = fmt.Fprintln(timeline, e); err != nil {
+			return err
+		}
+	}
+
+	cli.Output("Chaos run completed: %d event(s), %d failed\n", len(events), failures)
+	if failures > 0 {
+		return fmt.Errorf("%d node command(s) failed", failures)
+	}
+	return nil
+}
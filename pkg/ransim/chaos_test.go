@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChaosSchedule(t *testing.T) {
+	nodes := []types.GnbID{0x5152, 0x5153}
+	events := chaosSchedule(nodes, 2*time.Minute, 20*time.Second, time.Hour, 42)
+	assert.NotEmpty(t, events)
+	assert.Equal(t, events, chaosSchedule(nodes, 2*time.Minute, 20*time.Second, time.Hour, 42))
+
+	// Each node alternates between stop and start, and ends up started
+	last := make(map[types.GnbID]string)
+	for i, e := range events {
+		assert.LessOrEqual(t, e.at, time.Hour)
+		if i > 0 {
+			assert.LessOrEqual(t, events[i-1].at, e.at)
+		}
+		assert.NotEqual(t, last[e.gnbID], e.command)
+		last[e.gnbID] = e.command
+	}
+	for _, gnbID := range nodes {
+		assert.Equal(t, chaosStart, last[gnbID])
+	}
+
+	// The timeline replays to the same events
+	lines := []string{"# seed 42"}
+	for _, e := range events {
+		lines = append(lines, e.String())
+	}
+	replayed, err := readTimeline(strings.NewReader(strings.Join(lines, "\n")))
+	assert.NoError(t, err)
+	assert.Equal(t, events, replayed)
+
+	replayed, err = readTimeline(strings.NewReader("# seed 42\n1s stop 5152 # failed: rpc error: code = NotFound\n2s start 5152\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []chaosEvent{
+		{at: time.Second, command: chaosStop, gnbID: 0x5152},
+		{at: 2 * time.Second, command: chaosStart, gnbID: 0x5152},
+	}, replayed)
+
+	_, err = readTimeline(strings.NewReader("1s reboot 5152"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,471 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// knownServiceModels describes the service models supported by the RAN simulator, for use in generated models
+var knownServiceModels = map[string]*modelServiceModel{
+	"kpm":    {ID: "1.3.6.1.4.1.53148.1.1.2.2", Description: "KPM Monitor", Version: "1.0.0"},
+	"rc":     {ID: "1.3.6.1.4.1.53148.1.1.2.3", Description: "RAN Control", Version: "1.0.0"},
+	"kpm2":   {ID: "1.3.6.1.4.1.53148.1.2.2.2", Description: "KPM 2 Monitor", Version: "2.0.0"},
+	"rcpre2": {ID: "1.3.6.1.4.1.53148.1.2.2.100", Description: "RC PRE Control", Version: "2.0.0"},
+	"mho":    {ID: "1.3.6.1.4.1.53148.1.2.2.101", Description: "MHO Control", Version: "2.0.0"},
+}
+
+// deploymentSpec describes a hex-grid deployment of multi-sector sites
+type deploymentSpec struct {
+	sites         int
+	sectors       int
+	isd           float64
+	center        *types.Point
+	plmnID        types.PlmnID
+	firstGnbID    types.GnbID
+	maxPci        uint32
+	txPowerDB     float64
+	maxUEs        uint32
+	controllers   []string
+	serviceModels []string
+	// reservedPcis are the PCIs of cells already in the simulator, which are not assigned to generated cells
+	reservedPcis map[uint32]bool
+}
+
+const (
+	// gnbIDBits and cellIDBits are the widths of the gNB ID and cell ID within the 36-bit NR cell identity
+	gnbIDBits  = 22
+	cellIDBits = 14
+)
+
+// deployment is the result of generating a hex-grid deployment
+type deployment struct {
+	nodes []*types.Node
+	cells []*types.Cell
+}
+
+func generateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate [<model.yaml>|-]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Generate a hex-grid RAN deployment",
+		Long: `Generate a RAN deployment of sites placed on a hex grid around the given center, each with one
+E2 node and the given number of evenly spread sectors. Neighbor relations are derived from the sector
+geometry and PCIs are assigned so that no cell shares its PCI with a neighbor or a neighbor's neighbor.
+The deployment is written as a model YAML file suitable for 'ransim load', or created in the running
+simulator when --live is given. Live deployments use the PLMN ID of the simulator, avoid the PCIs of its
+existing cells and are rejected if any of the generated nodes or cells already exists.`,
+		RunE: runGenerateCommand,
+	}
+	cmd.Flags().Int("sites", 7, "number of sites")
+	cmd.Flags().Int("sectors", 3, "number of sectors, i.e. cells, per site")
+	cmd.Flags().String("isd", "500m", "inter-site distance, e.g. 500m or 1.2km")
+	cmd.Flags().String("center", "52.5200,13.4050", "center of the grid as lat,lng")
+	cmd.Flags().String("plmnid", "138426", "PLMN ID of the generated model; must match that of the simulator with --live")
+	cmd.Flags().String("first-gnbid", "5152", "gNB ID (hex) of the first site")
+	cmd.Flags().Uint32("max-pci", 1007, "highest PCI to assign")
+	cmd.Flags().Float64("tx-power", 11.0, "transmit power (dB)")
+	cmd.Flags().Uint32("max-ues", 10000, "maximum number of UEs connected per cell")
+	cmd.Flags().StringSlice("controllers", []string{"e2t-1"}, "E2T controllers of the nodes")
+	cmd.Flags().String("e2t-address", "onos-e2t:36401", "address of the E2T controllers in the generated model")
+	cmd.Flags().StringSlice("service-models", []string{"kpm2", "rcpre2", "mho"}, "service models supported by the nodes")
+	cmd.Flags().Uint32("ue-count", 0, "number of UEs in the generated model")
+	cmd.Flags().Bool("live", false, "create the nodes and cells in the running simulator")
+	return cmd
+}
+
+// parseDistance parses a distance in meters, optionally suffixed by m or km
+func parseDistance(s string) (float64, error) {
+	scale := 1.0
+	value := strings.TrimSpace(strings.ToLower(s))
+	if strings.HasSuffix(value, "km") {
+		scale, value = 1000, strings.TrimSuffix(value, "km")
+	} else {
+		value = strings.TrimSuffix(value, "m")
+	}
+	d, err := strconv.ParseFloat(value, 64)
+	if err != nil || d <= 0 {
+		return 0, errors.NewInvalid("invalid distance %s", s)
+	}
+	return d * scale, nil
+}
+
+// parseLatLng parses a lat,lng pair
+func parseLatLng(s string) (*types.Point, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return nil, errors.NewInvalid("invalid location %s; expected lat,lng", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid latitude %s", parts[0])
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid longitude %s", parts[1])
+	}
+	return &types.Point{Lat: lat, Lng: lng}, nil
+}
+
+// parsePlmnID parses a PLMN ID given as its 5 or 6 MCC and MNC digits
+func parsePlmnID(s string) (types.PlmnID, error) {
+	if len(s) < 5 || len(s) > 6 {
+		return 0, errors.NewInvalid("invalid PLMN ID %s; expected 5 or 6 digits", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, errors.NewInvalid("invalid PLMN ID %s; expected 5 or 6 digits", s)
+		}
+	}
+	return types.PlmnIDFromString(s), nil
+}
+
+// hexGrid returns the axial coordinates of the first n positions of a hex grid, spiralling out from the origin
+func hexGrid(n int) [][2]int {
+	// Axial directions, walked in order around each ring
+	directions := [6][2]int{{1, -1}, {1, 0}, {0, 1}, {-1, 1}, {-1, 0}, {0, -1}}
+	positions := [][2]int{{0, 0}}
+	for ring := 1; len(positions) < n; ring++ {
+		// Start each ring at its west-most position
+		q, r := -ring, 0
+		for side := 0; side < 6 && len(positions) < n; side++ {
+			for step := 0; step < ring && len(positions) < n; step++ {
+				positions = append(positions, [2]int{q, r})
+				q, r = q+directions[side][0], r+directions[side][1]
+			}
+		}
+	}
+	return positions[:n]
+}
+
+// offset returns the point at the given east and north offsets, in meters, from the origin
+func offset(origin *types.Point, east float64, north float64) *types.Point {
+	lat := origin.Lat + north/earthRadiusMeters*180/math.Pi
+	lng := origin.Lng + east/(earthRadiusMeters*math.Cos(origin.Lat*math.Pi/180))*180/math.Pi
+	return &types.Point{Lat: lat, Lng: lng}
+}
+
+// generateDeployment computes the sites, sectors, neighbors and PCIs of a hex-grid deployment
+func generateDeployment(spec *deploymentSpec) (*deployment, error) {
+	if spec.sites < 1 || spec.sectors < 1 {
+		return nil, errors.NewInvalid("at least one site with one sector is required")
+	}
+	if spec.sectors >= 1<<cellIDBits {
+		return nil, errors.NewInvalid("at most %d sectors fit in the %d-bit cell ID", 1<<cellIDBits-1, cellIDBits)
+	}
+	if uint64(spec.firstGnbID)+uint64(spec.sites) > 1<<gnbIDBits {
+		return nil, errors.NewInvalid("gNB IDs %x to %x do not fit in %d bits", spec.firstGnbID,
+			uint64(spec.firstGnbID)+uint64(spec.sites)-1, gnbIDBits)
+	}
+	arc := int32(360 / spec.sectors)
+
+	// Sector coverage centroids are used to derive neighbor relations; in a regular 3-sector layout, the
+	// centroids of facing and adjacent sectors are at most 0.87 ISD apart, the next tier 1 ISD apart
+	centroidDistance, neighborDistance := spec.isd/3, 0.9*spec.isd
+	if spec.sectors == 1 {
+		centroidDistance, neighborDistance = 0, 1.1*spec.isd
+	}
+
+	d := &deployment{}
+	centroids := make([]*types.Point, 0, spec.sites*spec.sectors)
+	sites := make([]int, 0, spec.sites*spec.sectors)
+	for i, pos := range hexGrid(spec.sites) {
+		q, r := float64(pos[0]), float64(pos[1])
+		location := offset(spec.center, spec.isd*(q+r/2), -spec.isd*r*math.Sqrt(3)/2)
+		gnbID := spec.firstGnbID + types.GnbID(i)
+
+		node := &types.Node{
+			GnbID:         gnbID,
+			Controllers:   spec.controllers,
+			ServiceModels: spec.serviceModels,
+		}
+		for s := 0; s < spec.sectors; s++ {
+			azimuth := int32(s * 360 / spec.sectors)
+			cell := &types.Cell{
+				NCGI:      types.ToNCGI(spec.plmnID, types.ToNCI(gnbID, types.CellID(s+1))),
+				Location:  location,
+				Sector:    &types.Sector{Azimuth: azimuth, Arc: arc},
+				Color:     "green",
+				MaxUEs:    spec.maxUEs,
+				TxPowerdB: spec.txPowerDB,
+				MeasurementParams: &types.MeasurementParams{
+					NcellIndividualOffsets: make(map[types.NCGI]int32),
+					EventA3Params:          &types.EventA3Params{},
+				},
+			}
+			node.CellNCGIs = append(node.CellNCGIs, cell.NCGI)
+			d.cells = append(d.cells, cell)
+			centroids = append(centroids, destination(location, float64(azimuth), centroidDistance))
+			sites = append(sites, i)
+		}
+		d.nodes = append(d.nodes, node)
+	}
+
+	for i, cell := range d.cells {
+		for j, other := range d.cells {
+			if i != j && (sites[i] == sites[j] || distanceMeters(centroids[i], centroids[j]) <= neighborDistance) {
+				cell.Neighbors = append(cell.Neighbors, other.NCGI)
+				cell.MeasurementParams.NcellIndividualOffsets[other.NCGI] = 0
+			}
+		}
+	}
+
+	if err := assignPCIs(d.cells, spec.maxPci, spec.reservedPcis); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// assignPCIs greedily assigns each cell the lowest PCI not used by its neighbors, which avoids collisions,
+// or by its neighbors' neighbors, which avoids confusion; reserved PCIs are never assigned
+func assignPCIs(cells []*types.Cell, maxPci uint32, reserved map[uint32]bool) error {
+	byNCGI := make(map[types.NCGI]*types.Cell, len(cells))
+	for _, cell := range cells {
+		byNCGI[cell.NCGI] = cell
+	}
+	assigned := make(map[types.NCGI]bool, len(cells))
+
+	for _, cell := range cells {
+		used := make(map[uint32]bool)
+		for _, n := range cell.Neighbors {
+			if neighbor, ok := byNCGI[n]; ok {
+				if assigned[n] {
+					used[neighbor.Pci] = true
+				}
+				for _, nn := range neighbor.Neighbors {
+					if assigned[nn] && nn != cell.NCGI {
+						used[byNCGI[nn].Pci] = true
+					}
+				}
+			}
+		}
+		pci := uint32(0)
+		for used[pci] || reserved[pci] {
+			pci++
+		}
+		if pci > maxPci {
+			return errors.NewInvalid("unable to assign PCI to cell %x within 0..%d", cell.NCGI, maxPci)
+		}
+		cell.Pci = pci
+		assigned[cell.NCGI] = true
+	}
+	return nil
+}
+
+func getDeploymentSpec(cmd *cobra.Command) (*deploymentSpec, error) {
+	spec := &deploymentSpec{}
+	spec.sites, _ = cmd.Flags().GetInt("sites")
+	spec.sectors, _ = cmd.Flags().GetInt("sectors")
+	spec.maxPci, _ = cmd.Flags().GetUint32("max-pci")
+	spec.txPowerDB, _ = cmd.Flags().GetFloat64("tx-power")
+	spec.maxUEs, _ = cmd.Flags().GetUint32("max-ues")
+	spec.controllers, _ = cmd.Flags().GetStringSlice("controllers")
+	spec.serviceModels, _ = cmd.Flags().GetStringSlice("service-models")
+
+	var err error
+	isd, _ := cmd.Flags().GetString("isd")
+	if spec.isd, err = parseDistance(isd); err != nil {
+		return nil, err
+	}
+	center, _ := cmd.Flags().GetString("center")
+	if spec.center, err = parseLatLng(center); err != nil {
+		return nil, err
+	}
+	firstGnbID, _ := cmd.Flags().GetString("first-gnbid")
+	gnbID, err := strconv.ParseUint(firstGnbID, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+	spec.firstGnbID = types.GnbID(gnbID)
+	plmnID, _ := cmd.Flags().GetString("plmnid")
+	if spec.plmnID, err = parsePlmnID(plmnID); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func runGenerateCommand(cmd *cobra.Command, args []string) error {
+	spec, err := getDeploymentSpec(cmd)
+	if err != nil {
+		return err
+	}
+
+	if live, _ := cmd.Flags().GetBool("live"); live {
+		return runGenerateLive(cmd, spec)
+	}
+
+	d, err := generateDeployment(spec)
+	if err != nil {
+		return err
+	}
+	e2tAddress, _ := cmd.Flags().GetString("e2t-address")
+	ueCount, _ := cmd.Flags().GetUint32("ue-count")
+	model, err := toDeploymentModel(spec, d, e2tAddress, ueCount)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(model)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || args[0] == "-" {
+		_, err = cli.GetOutput().Write(data)
+		return err
+	}
+	if err = ioutil.WriteFile(args[0], data, 0644); err != nil {
+		return err
+	}
+	cli.Output("Generated %d nodes and %d cells in %s\n", len(d.nodes), len(d.cells), args[0])
+	return nil
+}
+
+// toDeploymentModel assembles a model file for the given deployment
+func toDeploymentModel(spec *deploymentSpec, d *deployment, e2tAddress string, ueCount uint32) (*modelFile, error) {
+	host, port, err := net.SplitHostPort(e2tAddress)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid E2T address %s: %v", e2tAddress, err)
+	}
+	e2tPort, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid E2T port %s", port)
+	}
+
+	model := &modelFile{
+		PlmnID: types.PlmnIDToString(spec.plmnID),
+		Layout: &modelLayout{
+			Center:         toModelCoordinate(spec.center),
+			Zoom:           13,
+			Fade:           true,
+			ShowRoutes:     true,
+			ShowPower:      true,
+			LocationsScale: 1.0,
+		},
+		Nodes:         make(map[string]*modelNode, len(d.nodes)),
+		Cells:         make(map[string]*modelCell, len(d.cells)),
+		Controllers:   make(map[string]*modelController, len(spec.controllers)),
+		ServiceModels: make(map[string]*modelServiceModel, len(spec.serviceModels)),
+		UECount:       ueCount,
+	}
+	for _, node := range d.nodes {
+		model.Nodes[nodeKey(node.GnbID)] = toModelNode(node)
+	}
+	for _, cell := range d.cells {
+		model.Cells[cellKey(cell.NCGI)] = toModelCell(cell)
+	}
+	for _, name := range spec.controllers {
+		model.Controllers[name] = &modelController{ID: name, Address: host, Port: e2tPort}
+	}
+	for _, name := range spec.serviceModels {
+		sm, ok := knownServiceModels[name]
+		if !ok {
+			names := make([]string, 0, len(knownServiceModels))
+			for known := range knownServiceModels {
+				names = append(names, known)
+			}
+			sort.Strings(names)
+			return nil, errors.NewInvalid("unknown service model %s; expected one of %s", name, strings.Join(names, ","))
+		}
+		model.ServiceModels[name] = sm
+	}
+	return model, nil
+}
+
+// runGenerateLive creates the generated cells and nodes in the running simulator, using its PLMN ID; the
+// generated nodes and cells are checked against the existing ones before any of them is created
+func runGenerateLive(cmd *cobra.Command, spec *deploymentSpec) error {
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	nodeClient := modelapi.NewNodeModelClient(conn)
+	plmnID, err := nodeClient.GetPlmnID(ctx, &modelapi.PlmnIDRequest{})
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("plmnid") && spec.plmnID != plmnID.PlmnID {
+		return errors.NewInvalid("PLMN ID %s differs from the PLMN ID %s of the running simulator",
+			types.PlmnIDToString(spec.plmnID), types.PlmnIDToString(plmnID.PlmnID))
+	}
+	spec.plmnID = plmnID.PlmnID
+
+	nodes := make(map[types.GnbID]bool)
+	nodeStream, err := nodeClient.ListNodes(ctx, &modelapi.ListNodesRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		r, err := nodeStream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		nodes[r.Node.GnbID] = true
+	}
+
+	cells := make(map[types.NCGI]bool)
+	spec.reservedPcis = make(map[uint32]bool)
+	cellClient := modelapi.NewCellModelClient(conn)
+	cellStream, err := cellClient.ListCells(ctx, &modelapi.ListCellsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		r, err := cellStream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		cells[r.Cell.NCGI] = true
+		spec.reservedPcis[r.Cell.Pci] = true
+	}
+
+	d, err := generateDeployment(spec)
+	if err != nil {
+		return err
+	}
+	for _, node := range d.nodes {
+		if nodes[node.GnbID] {
+			return errors.NewAlreadyExists("node %x already exists; use --first-gnbid to generate nodes after it", node.GnbID)
+		}
+	}
+	for _, cell := range d.cells {
+		if cells[cell.NCGI] {
+			return errors.NewAlreadyExists("cell %x already exists", cell.NCGI)
+		}
+	}
+
+	for _, cell := range d.cells {
+		if _, err = cellClient.CreateCell(ctx, &modelapi.CreateCellRequest{Cell: cell}); err != nil {
+			return err
+		}
+	}
+	for _, node := range d.nodes {
+		if _, err = nodeClient.CreateNode(ctx, &modelapi.CreateNodeRequest{Node: node}); err != nil {
+			return err
+		}
+	}
+	cli.Output("Created %d nodes and %d cells\n", len(d.nodes), len(d.cells))
+	return nil
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_HexGrid(t *testing.T) {
+	positions := hexGrid(19)
+	assert.Len(t, positions, 19)
+	seen := make(map[[2]int]bool)
+	for _, pos := range positions {
+		assert.False(t, seen[pos], "duplicate position %v", pos)
+		seen[pos] = true
+		// Axial distance from the origin; the first 19 positions cover rings 0 to 2
+		q, r := pos[0], pos[1]
+		d := (abs(q) + abs(r) + abs(q+r)) / 2
+		assert.LessOrEqual(t, d, 2)
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func Test_GenerateDeployment(t *testing.T) {
+	spec := &deploymentSpec{
+		sites:      19,
+		sectors:    3,
+		isd:        500,
+		center:     &types.Point{Lat: 52.52, Lng: 13.405},
+		plmnID:     types.PlmnIDFromString("138426"),
+		firstGnbID: 0x5152,
+		maxPci:     1007,
+		maxUEs:     10000,
+	}
+	d, err := generateDeployment(spec)
+	assert.NoError(t, err)
+	assert.Len(t, d.nodes, 19)
+	assert.Len(t, d.cells, 57)
+
+	byNCGI := make(map[types.NCGI]*types.Cell)
+	for _, cell := range d.cells {
+		assert.Nil(t, byNCGI[cell.NCGI], "duplicate NCGI %x", cell.NCGI)
+		byNCGI[cell.NCGI] = cell
+	}
+	for _, cell := range d.cells {
+		assert.NotEmpty(t, cell.Neighbors)
+		for _, n := range cell.Neighbors {
+			neighbor := byNCGI[n]
+			assert.Contains(t, neighbor.Neighbors, cell.NCGI, "asymmetric neighbors %x and %x", cell.NCGI, n)
+			assert.NotEqual(t, cell.Pci, neighbor.Pci, "PCI collision between %x and %x", cell.NCGI, n)
+			for _, nn := range neighbor.Neighbors {
+				if nn != cell.NCGI {
+					assert.NotEqual(t, cell.Pci, byNCGI[nn].Pci, "PCI confusion between %x and %x", cell.NCGI, nn)
+				}
+			}
+		}
+	}
+
+	// The center site has its own two sectors and six others as neighbors of each cell
+	assert.Len(t, d.cells[0].Neighbors, 8)
+
+	spec.controllers = []string{"e2t-1"}
+	spec.serviceModels = []string{"kpm2", "mho"}
+	model, err := toDeploymentModel(spec, d, "onos-e2t:36401", 100)
+	assert.NoError(t, err)
+	assert.Len(t, model.Cells, 57)
+	assert.Equal(t, 36401, model.Controllers["e2t-1"].Port)
+	_, err = yaml.Marshal(model)
+	assert.NoError(t, err)
+
+	spec.serviceModels = []string{"bogus"}
+	_, err = toDeploymentModel(spec, d, "onos-e2t:36401", 0)
+	assert.Error(t, err)
+}
+
+func Test_GenerateDeploymentLimits(t *testing.T) {
+	spec := &deploymentSpec{
+		sites:      1,
+		sectors:    3,
+		isd:        500,
+		center:     &types.Point{Lat: 52.52, Lng: 13.405},
+		plmnID:     types.PlmnIDFromString("138426"),
+		firstGnbID: 0x3ffffe,
+		maxPci:     1007,
+	}
+	_, err := generateDeployment(spec)
+	assert.NoError(t, err)
+
+	spec.sites = 3
+	_, err = generateDeployment(spec)
+	assert.Error(t, err)
+
+	spec.sites, spec.firstGnbID, spec.sectors = 1, 0x5152, 1<<14
+	_, err = generateDeployment(spec)
+	assert.Error(t, err)
+
+	spec.sectors = 3
+	spec.reservedPcis = map[uint32]bool{0: true, 2: true}
+	d, err := generateDeployment(spec)
+	assert.NoError(t, err)
+	for _, cell := range d.cells {
+		assert.False(t, spec.reservedPcis[cell.Pci], "reserved PCI %d assigned to %x", cell.Pci, cell.NCGI)
+	}
+}
+
+func Test_ParseDistance(t *testing.T) {
+	d, err := parseDistance("500m")
+	assert.NoError(t, err)
+	assert.Equal(t, 500.0, d)
+	d, err = parseDistance("1.2km")
+	assert.NoError(t, err)
+	assert.Equal(t, 1200.0, d)
+	_, err = parseDistance("-5")
+	assert.Error(t, err)
+}
+
+func Test_ParsePlmnID(t *testing.T) {
+	plmnID, err := parsePlmnID("138426")
+	assert.NoError(t, err)
+	assert.Equal(t, types.PlmnIDFromString("138426"), plmnID)
+	_, err = parsePlmnID("13842")
+	assert.NoError(t, err)
+	for _, s := range []string{"", "1", "1384", "1384267", "13842a"} {
+		_, err = parsePlmnID(s)
+		assert.Error(t, err, s)
+	}
+}
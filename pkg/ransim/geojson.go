@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const (
+	earthRadiusMeters = 6371000.0
+	// number of vertices used to approximate the coverage arc of a sector
+	sectorArcSteps = 32
+)
+
+type geoJSONFeatureCollection struct {
+	Type     string            `json:"type"`
+	Features []*geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *geoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func getGeoJSONCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "geojson [<file.geojson>|-]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Get cells, UEs and routes as a GeoJSON feature collection",
+		RunE:  runGetGeoJSONCommand,
+	}
+	cmd.Flags().Float64("range", 1000, "range of the cell sector polygons in meters")
+	cmd.Flags().Bool("no-cells", false, "omit the cell sectors")
+	cmd.Flags().Bool("no-ues", false, "omit the UEs")
+	cmd.Flags().Bool("no-routes", false, "omit the routes")
+	return cmd
+}
+
+// toGeoJSONPosition returns the GeoJSON position, i.e. longitude first, of the given point
+func toGeoJSONPosition(p *types.Point) []float64 {
+	return []float64{p.Lng, p.Lat}
+}
+
+// destination returns the point at the given distance and compass bearing from the origin
+func destination(origin *types.Point, bearing float64, distance float64) *types.Point {
+	lat1 := origin.Lat * math.Pi / 180
+	lng1 := origin.Lng * math.Pi / 180
+	theta := bearing * math.Pi / 180
+	delta := distance / earthRadiusMeters
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	return &types.Point{Lat: lat2 * 180 / math.Pi, Lng: lng2 * 180 / math.Pi}
+}
+
+// sectorPolygon returns the closed ring covering the arc of a sector centered on its azimuth;
+// sectors without an arc, or with a full arc, are treated as omni-directional. Compass bearings
+// increase clockwise, so they are walked backwards to wind the ring counterclockwise per RFC 7946
+func sectorPolygon(center *types.Point, azimuth int32, arc int32, distance float64) [][]float64 {
+	ring := make([][]float64, 0, sectorArcSteps+3)
+	if arc <= 0 || arc >= 360 {
+		for i := 0; i < sectorArcSteps; i++ {
+			ring = append(ring, toGeoJSONPosition(destination(center, 360-float64(i)*360/sectorArcSteps, distance)))
+		}
+		return append(ring, ring[0])
+	}
+
+	end := float64(azimuth) + float64(arc)/2
+	ring = append(ring, toGeoJSONPosition(center))
+	for i := 0; i <= sectorArcSteps; i++ {
+		ring = append(ring, toGeoJSONPosition(destination(center, end-float64(i)*float64(arc)/sectorArcSteps, distance)))
+	}
+	return append(ring, toGeoJSONPosition(center))
+}
+
+func cellFeature(cell *types.Cell, distance float64) *geoJSONFeature {
+	var azimuth, arc int32
+	if cell.Sector != nil {
+		azimuth, arc = cell.Sector.Azimuth, cell.Sector.Arc
+	}
+	return &geoJSONFeature{
+		Type: "Feature",
+		Geometry: &geoJSONGeometry{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{sectorPolygon(cell.Location, azimuth, arc, distance)},
+		},
+		Properties: map[string]interface{}{
+			"kind":      "cell",
+			"ncgi":      fmt.Sprintf("%x", cell.NCGI),
+			"pci":       cell.Pci,
+			"azimuth":   azimuth,
+			"arc":       arc,
+			"txPowerdB": cell.TxPowerdB,
+			"color":     cell.Color,
+			"ues":       len(cell.CrntiMap),
+		},
+	}
+}
+
+func ueFeature(ue *types.Ue) *geoJSONFeature {
+	return &geoJSONFeature{
+		Type: "Feature",
+		Geometry: &geoJSONGeometry{
+			Type:        "Point",
+			Coordinates: toGeoJSONPosition(ue.Position),
+		},
+		Properties: map[string]interface{}{
+			"kind":        "ue",
+			"imsi":        ue.IMSI,
+			"servingCell": fmt.Sprintf("%x", ue.ServingTower),
+			"strength":    ue.ServingTowerStrength,
+			"rrcState":    rrcStatusName[int32(ue.RrcState)],
+			"admitted":    ue.Admitted,
+		},
+	}
+}
+
+func routeFeature(route *types.Route) *geoJSONFeature {
+	positions := make([][]float64, 0, len(route.Waypoints))
+	for _, p := range route.Waypoints {
+		positions = append(positions, toGeoJSONPosition(p))
+	}
+	return &geoJSONFeature{
+		Type: "Feature",
+		Geometry: &geoJSONGeometry{
+			Type:        "LineString",
+			Coordinates: positions,
+		},
+		Properties: map[string]interface{}{
+			"kind":  "route",
+			"imsi":  route.RouteID,
+			"color": route.Color,
+		},
+	}
+}
+
+func runGetGeoJSONCommand(cmd *cobra.Command, args []string) error {
+	distance, _ := cmd.Flags().GetFloat64("range")
+	noCells, _ := cmd.Flags().GetBool("no-cells")
+	noUEs, _ := cmd.Flags().GetBool("no-ues")
+	noRoutes, _ := cmd.Flags().GetBool("no-routes")
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	collection := &geoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]*geoJSONFeature, 0)}
+	if !noCells {
+		if err = addCellFeatures(conn, collection, distance); err != nil {
+			return err
+		}
+	}
+	if !noUEs {
+		if err = addUEFeatures(conn, collection); err != nil {
+			return err
+		}
+	}
+	if !noRoutes {
+		if err = addRouteFeatures(conn, collection); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err = cli.GetOutput().Write(data)
+		return err
+	}
+	return ioutil.WriteFile(args[0], data, 0644)
+}
+
+func addCellFeatures(conn *grpc.ClientConn, collection *geoJSONFeatureCollection, distance float64) error {
+	stream, err := modelapi.NewCellModelClient(conn).ListCells(context.Background(), &modelapi.ListCellsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if r.Cell.Location != nil {
+			collection.Features = append(collection.Features, cellFeature(r.Cell, distance))
+		}
+	}
+}
+
+func addUEFeatures(conn *grpc.ClientConn, collection *geoJSONFeatureCollection) error {
+	stream, err := modelapi.NewUEModelClient(conn).ListUEs(context.Background(), &modelapi.ListUEsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if r.Ue.Position != nil {
+			collection.Features = append(collection.Features, ueFeature(r.Ue))
+		}
+	}
+}
+
+func addRouteFeatures(conn *grpc.ClientConn, collection *geoJSONFeatureCollection) error {
+	stream, err := modelapi.NewRouteModelClient(conn).ListRoutes(context.Background(), &modelapi.ListRoutesRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(r.Route.Waypoints) > 1 {
+			collection.Features = append(collection.Features, routeFeature(r.Route))
+		}
+	}
+}
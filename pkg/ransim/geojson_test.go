@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Destination(t *testing.T) {
+	origin := &types.Point{Lat: 52.0, Lng: 13.0}
+
+	north := destination(origin, 0, 1000)
+	assert.InDelta(t, 52.009, north.Lat, 0.001)
+	assert.InDelta(t, 13.0, north.Lng, 1e-9)
+
+	east := destination(origin, 90, 1000)
+	assert.InDelta(t, 52.0, east.Lat, 0.0001)
+	assert.Greater(t, east.Lng, 13.0)
+}
+
+func Test_SectorPolygon(t *testing.T) {
+	center := &types.Point{Lat: 52.0, Lng: 13.0}
+
+	ring := sectorPolygon(center, 90, 120, 1000)
+	assert.Len(t, ring, sectorArcSteps+3)
+	assert.Equal(t, []float64{13.0, 52.0}, ring[0])
+	assert.Equal(t, ring[0], ring[len(ring)-1])
+	// The middle of the arc points along the azimuth, i.e. east
+	assert.InDelta(t, 52.0, ring[sectorArcSteps/2+1][1], 0.0001)
+	assert.Greater(t, ring[sectorArcSteps/2+1][0], 13.0)
+	assert.Greater(t, signedArea(ring), 0.0)
+
+	omni := sectorPolygon(center, 0, 360, 1000)
+	assert.Len(t, omni, sectorArcSteps+1)
+	assert.Equal(t, omni[0], omni[len(omni)-1])
+	assert.Greater(t, signedArea(omni), 0.0)
+}
+
+// signedArea returns the shoelace area of a closed ring, which is positive for counterclockwise rings
+func signedArea(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
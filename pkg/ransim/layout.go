@@ -6,11 +6,16 @@ package ransim
 
 import (
 	"context"
+	"io/ioutil"
+	"math"
 
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
 	simapi "github.com/onosproject/onos-api/go/onos/ransim/trafficsim"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 func getLayoutCommand() *cobra.Command {
@@ -39,3 +44,172 @@ func runGetLayoutCommand(cmd *cobra.Command, _ []string) error {
 		ml.Center.Lat, ml.Center.Lng, ml.Zoom, ml.Fade, ml.ShowRoutes, ml.ShowPower, ml.LocationsScale)
 	return nil
 }
+
+func setLayoutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "layout [field options]",
+		Args:  cobra.NoArgs,
+		Short: "Set Layout; changing the live layout restarts the simulation",
+		Long: `Set the map layout fields of a model YAML file given by --model-file, or of the live model.
+The simulator offers no call to change only the map layout; the live model is therefore exported, given
+the new layout and loaded again with --reload, with the controllers and service models taken from the model
+YAML file given by --base. Loading the model restarts the simulation, dropping the state of its UEs, routes
+and metrics; to avoid that, update the model YAML file with --model-file and load it when convenient.`,
+		RunE: runSetLayoutCommand,
+	}
+	cmd.Flags().Float64("center-lat", 0, "map center latitude")
+	cmd.Flags().Float64("center-lng", 0, "map center longitude")
+	cmd.Flags().Float32("zoom", 0, "map zoom level")
+	cmd.Flags().Bool("fade", false, "fade the map")
+	cmd.Flags().Bool("show-routes", false, "show UE routes")
+	cmd.Flags().Bool("show-power", false, "show cell power")
+	cmd.Flags().Float32("locations-scale", 0, "scale of the UE and cell locations")
+	cmd.Flags().Bool("auto-fit", false, "compute the center and zoom from the bounding box of all cells")
+	cmd.Flags().String("model-file", "", "model YAML file to update instead of the live model")
+	cmd.Flags().Bool("reload", false, "reload the live model with the new layout, restarting the simulation")
+	cmd.Flags().String("base", "", "model YAML file from which to retain the sections not available from the live model")
+	return cmd
+}
+
+const maxZoom = 16.0
+
+// fitLayout returns the center of the bounding box of the cells and the highest zoom level at which the
+// box fits a 1024x768 map view; it returns false if there are no cells
+func fitLayout(cells map[string]*modelCell) (modelCoordinate, float32, bool) {
+	if len(cells) == 0 {
+		return modelCoordinate{}, 0, false
+	}
+	minLat, minLng := math.Inf(1), math.Inf(1)
+	maxLat, maxLng := math.Inf(-1), math.Inf(-1)
+	for _, cell := range cells {
+		c := cell.Sector.Center
+		minLat, maxLat = math.Min(minLat, c.Lat), math.Max(maxLat, c.Lat)
+		minLng, maxLng = math.Min(minLng, c.Lng), math.Max(maxLng, c.Lng)
+	}
+	center := modelCoordinate{Lat: (minLat + maxLat) / 2, Lng: (minLng + maxLng) / 2}
+
+	// Web Mercator tiles are 256 pixels wide and span 360 degrees at zoom 0
+	zoom := maxZoom
+	if lngSpan := maxLng - minLng; lngSpan > 0 {
+		zoom = math.Min(zoom, math.Log2(1024*360/(256*lngSpan)))
+	}
+	if latSpan := (maxLat - minLat) / math.Cos(center.Lat*math.Pi/180); latSpan > 0 {
+		zoom = math.Min(zoom, math.Log2(768*360/(256*latSpan)))
+	}
+	// Leave a margin around the cells
+	zoom = math.Max(1, math.Floor((zoom-0.5)*4)/4)
+	return center, float32(zoom), true
+}
+
+// applyLayoutOptions updates the layout with the fields given on the command line
+func applyLayoutOptions(cmd *cobra.Command, layout *modelLayout, cells map[string]*modelCell) error {
+	if autoFit, _ := cmd.Flags().GetBool("auto-fit"); autoFit {
+		center, zoom, ok := fitLayout(cells)
+		if !ok {
+			return errors.NewInvalid("no cells to fit the layout to")
+		}
+		layout.Center, layout.Zoom = center, zoom
+	}
+	if cmd.Flags().Changed("center-lat") {
+		layout.Center.Lat, _ = cmd.Flags().GetFloat64("center-lat")
+	}
+	if cmd.Flags().Changed("center-lng") {
+		layout.Center.Lng, _ = cmd.Flags().GetFloat64("center-lng")
+	}
+	if cmd.Flags().Changed("zoom") {
+		layout.Zoom, _ = cmd.Flags().GetFloat32("zoom")
+	}
+	if cmd.Flags().Changed("fade") {
+		layout.Fade, _ = cmd.Flags().GetBool("fade")
+	}
+	if cmd.Flags().Changed("show-routes") {
+		layout.ShowRoutes, _ = cmd.Flags().GetBool("show-routes")
+	}
+	if cmd.Flags().Changed("show-power") {
+		layout.ShowPower, _ = cmd.Flags().GetBool("show-power")
+	}
+	if cmd.Flags().Changed("locations-scale") {
+		layout.LocationsScale, _ = cmd.Flags().GetFloat32("locations-scale")
+	}
+	return nil
+}
+
+func runSetLayoutCommand(cmd *cobra.Command, _ []string) error {
+	if modelPath, _ := cmd.Flags().GetString("model-file"); modelPath != "" {
+		return setModelFileLayout(cmd, modelPath)
+	}
+
+	if reload, _ := cmd.Flags().GetBool("reload"); !reload {
+		return errors.NewInvalid("changing the live layout reloads the model and restarts the simulation; " +
+			"use --reload to do so, or --model-file to update a model YAML file instead")
+	}
+	base, _ := cmd.Flags().GetString("base")
+	if base == "" {
+		return errors.NewInvalid("--base is required to reload the live model; alternatively use --model-file")
+	}
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	model, err := readLiveModel(conn)
+	if err != nil {
+		return err
+	}
+	if err = applyLayoutOptions(cmd, model.Layout, model.Cells); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(model)
+	if err != nil {
+		return err
+	}
+	if data, err = mergeModelYAML(base, data); err != nil {
+		return err
+	}
+
+	client := modelapi.NewModelServiceClient(conn)
+	dataSet := []*modelapi.DataSet{{Type: "model", Data: data}}
+	if _, err = client.Load(context.Background(), &modelapi.LoadRequest{DataSet: dataSet, Resume: true}); err != nil {
+		return err
+	}
+	outputLayout(model.Layout)
+	return nil
+}
+
+// setModelFileLayout updates the layout section of a model YAML file, retaining its other sections
+func setModelFileLayout(cmd *cobra.Command, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	model := &modelFile{}
+	if err = yaml.Unmarshal(data, model); err != nil {
+		return err
+	}
+	if model.Layout == nil {
+		model.Layout = &modelLayout{LocationsScale: 1.0}
+	}
+	if err = applyLayoutOptions(cmd, model.Layout, model.Cells); err != nil {
+		return err
+	}
+
+	layout, err := yaml.Marshal(&modelFile{Layout: model.Layout})
+	if err != nil {
+		return err
+	}
+	if data, err = mergeModelYAML(path, layout); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	outputLayout(model.Layout)
+	return nil
+}
+
+func outputLayout(layout *modelLayout) {
+	cli.Output("Center: %7.3f,%7.3f\nZoom: %5.2f\nFade: %v\nShowRoutes: %v\nShowPower: %v\nLocationsScale: %5.2f\n",
+		layout.Center.Lat, layout.Center.Lng, layout.Zoom, layout.Fade, layout.ShowRoutes, layout.ShowPower, layout.LocationsScale)
+}
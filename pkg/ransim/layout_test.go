@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_FitLayout(t *testing.T) {
+	_, _, ok := fitLayout(nil)
+	assert.False(t, ok)
+
+	cells := map[string]*modelCell{
+		"cell-1": {Sector: modelSector{Center: modelCoordinate{Lat: 52.50, Lng: 13.30}}},
+		"cell-2": {Sector: modelSector{Center: modelCoordinate{Lat: 52.54, Lng: 13.50}}},
+	}
+	center, zoom, ok := fitLayout(cells)
+	assert.True(t, ok)
+	assert.InDelta(t, 52.52, center.Lat, 1e-9)
+	assert.InDelta(t, 13.40, center.Lng, 1e-9)
+	// log2(1440/0.2) is about 12.8, less the margin
+	assert.Equal(t, float32(12.25), zoom)
+
+	single := map[string]*modelCell{"cell-1": cells["cell-1"]}
+	_, zoom, _ = fitLayout(single)
+	assert.Equal(t, float32(maxZoom-0.5), zoom)
+}
+
+func Test_SetModelFileLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`layout:
+  center:
+    lat: 1
+    lng: 2
+  zoom: 10
+  locationsScale: 1.25
+cells:
+  cell-1:
+    ncgi: 1
+    sector:
+      center:
+        lat: 52.5
+        lng: 13.3
+  cell-2:
+    ncgi: 2
+    sector:
+      center:
+        lat: 52.54
+        lng: 13.5
+`), 0644))
+
+	cmd := setLayoutCommand()
+	assert.NoError(t, cmd.Flags().Parse([]string{"--auto-fit", "--show-routes", "--zoom", "11"}))
+	assert.NoError(t, setModelFileLayout(cmd, path))
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	model := &modelFile{}
+	assert.NoError(t, yaml.Unmarshal(data, model))
+	assert.InDelta(t, 52.52, model.Layout.Center.Lat, 1e-9)
+	assert.InDelta(t, 13.40, model.Layout.Center.Lng, 1e-9)
+	assert.Equal(t, float32(11), model.Layout.Zoom)
+	assert.True(t, model.Layout.ShowRoutes)
+	assert.Equal(t, float32(1.25), model.Layout.LocationsScale)
+	assert.Len(t, model.Cells, 2)
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	lintAsymmetricNeighbor = "asymmetric-neighbor"
+	lintUnknownNeighbor    = "unknown-neighbor"
+	lintPCICollision       = "pci-collision"
+	lintPCIConfusion       = "pci-confusion"
+	lintUnknownCell        = "unknown-cell"
+	lintMissingNeighbor    = "missing-neighbor"
+)
+
+// lintFinding is a problem found in a model
+type lintFinding struct {
+	check   string
+	subject string
+	detail  string
+}
+
+func lintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [<model.yaml>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Check a model for neighbor, PCI and coverage problems",
+		Long: `Check a model YAML file, or the live model with --live, for asymmetric neighbor relations, PCI
+collisions between neighbors, PCI confusion among the neighbors of a cell, nodes referencing cells
+that do not exist, and cells whose sectors overlap without being neighbors.`,
+		RunE: runLintCommand,
+	}
+	cmd.Flags().Bool("live", false, "check the model of the running simulator")
+	cmd.Flags().Float64("range", 1000, "range of the cell sectors in meters, used to detect overlapping sectors")
+	cmd.Flags().Bool("no-headers", false, "disables output headers")
+	return cmd
+}
+
+func runLintCommand(cmd *cobra.Command, args []string) error {
+	live, _ := cmd.Flags().GetBool("live")
+	sectorRange, _ := cmd.Flags().GetFloat64("range")
+	if live == (len(args) == 1) {
+		return errors.NewInvalid("either a model file or --live must be given")
+	}
+
+	var model *modelFile
+	if live {
+		conn, err := cli.GetConnection(cmd)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		if model, err = readLiveModel(conn); err != nil {
+			return err
+		}
+	} else {
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		model = &modelFile{}
+		if err = yaml.Unmarshal(data, model); err != nil {
+			return err
+		}
+	}
+
+	findings := lintModel(model, sectorRange)
+	if len(findings) == 0 {
+		cli.Output("No problems found in %d nodes and %d cells\n", len(model.Nodes), len(model.Cells))
+		return nil
+	}
+
+	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); !noHeaders {
+		cli.Output("%-20s %-16s %s\n", "Check", "Subject", "Detail")
+	}
+	for _, f := range findings {
+		cli.Output("%-20s %-16s %s\n", f.check, f.subject, f.detail)
+	}
+	return fmt.Errorf("%d problem(s) found", len(findings))
+}
+
+// lintModel returns the problems found in the given model, ordered by check and subject
+func lintModel(model *modelFile, sectorRange float64) []lintFinding {
+	cells := make(map[types.NCGI]*modelCell, len(model.Cells))
+	ncgis := make([]types.NCGI, 0, len(model.Cells))
+	for _, cell := range model.Cells {
+		cells[types.NCGI(cell.NCGI)] = cell
+		ncgis = append(ncgis, types.NCGI(cell.NCGI))
+	}
+	sort.Slice(ncgis, func(i, j int) bool { return ncgis[i] < ncgis[j] })
+
+	findings := make([]lintFinding, 0)
+	add := func(check string, subject string, format string, args ...interface{}) {
+		findings = append(findings, lintFinding{check: check, subject: subject, detail: fmt.Sprintf(format, args...)})
+	}
+
+	neighbors := make(map[types.NCGI]map[types.NCGI]bool, len(cells))
+	for _, ncgi := range ncgis {
+		neighbors[ncgi] = make(map[types.NCGI]bool)
+		for _, n := range cells[ncgi].Neighbors {
+			neighbors[ncgi][types.NCGI(n)] = true
+		}
+	}
+	related := func(a types.NCGI, b types.NCGI) bool {
+		return neighbors[a][b] || neighbors[b][a]
+	}
+
+	for _, ncgi := range ncgis {
+		cell := cells[ncgi]
+		subject := fmt.Sprintf("%x", ncgi)
+		pcis := make(map[uint32][]types.NCGI)
+
+		for _, n := range modelNCGIs(cell.Neighbors) {
+			neighbor, ok := cells[n]
+			if !ok {
+				add(lintUnknownNeighbor, subject, "neighbor %x does not exist", n)
+				continue
+			}
+			if !neighbors[n][ncgi] {
+				add(lintAsymmetricNeighbor, subject, "lists %x as neighbor, but not vice versa", n)
+			}
+			// Report collisions once per related pair
+			if neighbor.Pci == cell.Pci && (ncgi < n || !neighbors[n][ncgi]) {
+				add(lintPCICollision, subject, "shares PCI %d with neighbor %x", cell.Pci, n)
+			}
+			pcis[neighbor.Pci] = append(pcis[neighbor.Pci], n)
+		}
+
+		confused := make([]uint32, 0)
+		for pci, ns := range pcis {
+			if len(ns) > 1 {
+				confused = append(confused, pci)
+			}
+		}
+		sort.Slice(confused, func(i, j int) bool { return confused[i] < confused[j] })
+		for _, pci := range confused {
+			add(lintPCIConfusion, subject, "neighbors %s share PCI %d", catNCGIs(pcis[pci]), pci)
+		}
+	}
+
+	nodeNames := make([]string, 0, len(model.Nodes))
+	for name := range model.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, name := range nodeNames {
+		missing := make([]types.NCGI, 0)
+		for _, ncgi := range modelNCGIs(model.Nodes[name].Cells) {
+			if _, ok := cells[ncgi]; !ok {
+				missing = append(missing, ncgi)
+			}
+		}
+		if len(missing) > 0 {
+			add(lintUnknownCell, name, "references missing cells %s", catNCGIs(missing))
+		}
+	}
+
+	for i, a := range ncgis {
+		for _, b := range ncgis[i+1:] {
+			if !related(a, b) && sectorsOverlap(cells[a], cells[b], sectorRange) {
+				add(lintMissingNeighbor, fmt.Sprintf("%x", a), "sector overlaps with %x, which is not a neighbor", b)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].check < findings[j].check })
+	return findings
+}
+
+// modelNCGIs converts the cell IDs of a model file into NCGIs
+func modelNCGIs(ids []uint64) []types.NCGI {
+	ncgis := make([]types.NCGI, 0, len(ids))
+	for _, id := range ids {
+		ncgis = append(ncgis, types.NCGI(id))
+	}
+	return ncgis
+}
+
+// bearing returns the compass bearing, in degrees, from a to b
+func bearing(a *types.Point, b *types.Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// inSector returns true if the point lies within the range and arc of the sector
+func inSector(sector *modelSector, p *types.Point, sectorRange float64) bool {
+	center := &types.Point{Lat: sector.Center.Lat, Lng: sector.Center.Lng}
+	d := distanceMeters(center, p)
+	if d > sectorRange+1 {
+		return false
+	}
+	if d < 1 || sector.Arc <= 0 || sector.Arc >= 360 {
+		return true
+	}
+	diff := math.Abs(math.Mod(bearing(center, p)-float64(sector.Azimuth)+540, 360) - 180)
+	return diff <= float64(sector.Arc)/2+0.5
+}
+
+// sectorsOverlap returns true if the outline of either sector reaches into the other
+func sectorsOverlap(a *modelCell, b *modelCell, sectorRange float64) bool {
+	outlines := func(x *modelCell, y *modelCell) bool {
+		center := &types.Point{Lat: x.Sector.Center.Lat, Lng: x.Sector.Center.Lng}
+		for _, pos := range sectorPolygon(center, x.Sector.Azimuth, x.Sector.Arc, sectorRange) {
+			if inSector(&y.Sector, &types.Point{Lat: pos[1], Lng: pos[0]}, sectorRange) {
+				return true
+			}
+		}
+		return false
+	}
+	return outlines(a, b) || outlines(b, a)
+}
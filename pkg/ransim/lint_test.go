@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func lintChecks(findings []lintFinding) []string {
+	checks := make([]string, 0, len(findings))
+	for _, f := range findings {
+		checks = append(checks, f.check)
+	}
+	return checks
+}
+
+func Test_LintGeneratedModel(t *testing.T) {
+	spec := &deploymentSpec{
+		sites:      7,
+		sectors:    3,
+		isd:        500,
+		center:     &types.Point{Lat: 52.52, Lng: 13.405},
+		plmnID:     types.PlmnIDFromString("138426"),
+		firstGnbID: 0x5152,
+		maxPci:     1007,
+	}
+	d, err := generateDeployment(spec)
+	assert.NoError(t, err)
+	model, err := toDeploymentModel(spec, d, "onos-e2t:36401", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, lintModel(model, 250))
+}
+
+func Test_LintModel(t *testing.T) {
+	sector := func(lat float64, azimuth int32) modelSector {
+		return modelSector{Center: modelCoordinate{Lat: lat, Lng: 13.0}, Azimuth: azimuth, Arc: 120}
+	}
+	model := &modelFile{
+		Cells: map[string]*modelCell{
+			"cell-1": {NCGI: 1, Pci: 10, Sector: sector(52.0, 0), Neighbors: []uint64{2, 3, 9}},
+			"cell-2": {NCGI: 2, Pci: 10, Sector: sector(52.0, 120), Neighbors: []uint64{1}},
+			"cell-3": {NCGI: 3, Pci: 20, Sector: sector(52.0, 240), Neighbors: []uint64{}},
+			"cell-4": {NCGI: 4, Pci: 10, Sector: sector(52.001, 180), Neighbors: []uint64{}},
+			"cell-5": {NCGI: 5, Pci: 30, Sector: sector(53.0, 0), Neighbors: []uint64{}},
+		},
+		Nodes: map[string]*modelNode{
+			"node-1": {GnbID: 1, Cells: []uint64{1, 2, 3, 8}},
+		},
+	}
+
+	findings := lintModel(model, 500)
+	assert.Equal(t, []string{
+		lintAsymmetricNeighbor, // 1 lists 3, but 3 does not list 1
+		lintMissingNeighbor,    // 1 and 4 face each other
+		lintMissingNeighbor,    // 2 and 3 are co-sited
+		lintMissingNeighbor,    // 2 and 4
+		lintMissingNeighbor,    // 3 and 4
+		lintPCICollision,       // 1 and 2 share PCI 10
+		lintUnknownCell,        // node-1 references 8
+		lintUnknownNeighbor,    // 1 lists 9
+	}, lintChecks(findings))
+}
@@ -7,6 +7,7 @@ package ransim
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	metricsapi "github.com/onosproject/onos-api/go/onos/ransim/metrics"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
@@ -49,6 +50,23 @@ func setMetricCommand() *cobra.Command {
 	return cmd
 }
 
+func setMetricsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics --from-csv <file.csv>",
+		Short: "Set metric values from a CSV time series",
+		Long: `Set metric values from a CSV file with the columns entity, name, type, value and an optional offset,
+e.g. '5153,load,float64,0.75,30s'. Rows are played back at their offset, a duration or a number of
+seconds from the start, divided by --speed; rows without an offset are set right away. A header row
+starting with 'entity' is skipped.`,
+		Args: cobra.NoArgs,
+		RunE: runSetMetricsCommand,
+	}
+	cmd.Flags().String("from-csv", "", "CSV file with the metric values")
+	cmd.Flags().Float64("speed", 1.0, "playback speed; 2 plays the time series twice as fast")
+	_ = cmd.MarkFlagRequired("from-csv")
+	return cmd
+}
+
 func deleteMetricCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "metric <entity-id> <metric-name>",
@@ -167,27 +185,57 @@ func runGetMetricsCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runSetMetricCommand(cmd *cobra.Command, args []string) error {
-	entityID, err := strconv.ParseUint(args[0], 16, 64)
+// parseMetric validates the given metric fields, in particular that the value can be parsed as the given type
+func parseMetric(entity string, name string, valueType string, value string) (*metricsapi.Metric, error) {
+	entityID, err := strconv.ParseUint(entity, 16, 64)
 	if err != nil {
-		return err
+		return nil, errors.NewInvalid("invalid entity ID %s", entity)
+	}
+	if name == "" {
+		return nil, errors.NewInvalid("metric name must not be empty")
 	}
-	name := args[1]
-	value := args[2]
-	valueType, _ := cmd.Flags().GetString("type")
 
-	client, conn, err := getMetricsClient(cmd)
+	switch valueType {
+	case "string":
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int8", "int16", "int32", "int64":
+		bits, _ := strconv.Atoi(strings.TrimPrefix(valueType, "int"))
+		_, err = strconv.ParseInt(value, 10, bits)
+	case "uint8", "uint16", "uint32", "uint64":
+		bits, _ := strconv.Atoi(strings.TrimPrefix(valueType, "uint"))
+		_, err = strconv.ParseUint(value, 10, bits)
+	case "float32", "float64":
+		bits, _ := strconv.Atoi(strings.TrimPrefix(valueType, "float"))
+		_, err = strconv.ParseFloat(value, bits)
+	default:
+		return nil, errors.NewInvalid("invalid value type %s; expected string|intX|uintX|floatX|bool; where X={8|16|32|64}", valueType)
+	}
 	if err != nil {
-		return err
+		return nil, errors.NewInvalid("invalid %s value %s", valueType, value)
 	}
-	defer conn.Close()
 
-	metric := &metricsapi.Metric{
+	return &metricsapi.Metric{
 		EntityID: entityID,
 		Key:      name,
 		Value:    value,
 		Type:     valueType,
+	}, nil
+}
+
+func runSetMetricCommand(cmd *cobra.Command, args []string) error {
+	valueType, _ := cmd.Flags().GetString("type")
+	metric, err := parseMetric(args[0], args[1], valueType, args[2])
+	if err != nil {
+		return err
 	}
+
+	client, conn, err := getMetricsClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
 	_, err = client.Set(context.Background(), &metricsapi.SetRequest{Metric: metric})
 	if err != nil {
 		return err
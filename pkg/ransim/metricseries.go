@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metricsapi "github.com/onosproject/onos-api/go/onos/ransim/metrics"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// metricSample is a metric value to be set at an offset from the start of playback
+type metricSample struct {
+	at     time.Duration
+	metric *metricsapi.Metric
+}
+
+// parseOffset parses a duration such as 1m30s, or a plain number of seconds
+func parseOffset(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// readMetricSeries reads and validates the rows of a metric CSV file, ordered by offset
+func readMetricSeries(r io.Reader) ([]*metricSample, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	samples := make([]*metricSample, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if row == 1 && strings.EqualFold(record[0], "entity") {
+			continue
+		}
+		if len(record) < 4 || len(record) > 5 {
+			return nil, errors.NewInvalid("row %d: expected entity, name, type, value and an optional offset", row)
+		}
+
+		metric, err := parseMetric(record[0], record[1], record[2], record[3])
+		if err != nil {
+			return nil, errors.NewInvalid("row %d: %v", row, err)
+		}
+		sample := &metricSample{metric: metric}
+		if len(record) == 5 && record[4] != "" {
+			if sample.at, err = parseOffset(record[4]); err != nil || sample.at < 0 {
+				return nil, errors.NewInvalid("row %d: invalid offset %s", row, record[4])
+			}
+		}
+		samples = append(samples, sample)
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].at < samples[j].at })
+	return samples, nil
+}
+
+func runSetMetricsCommand(cmd *cobra.Command, _ []string) error {
+	path, _ := cmd.Flags().GetString("from-csv")
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	if speed <= 0 {
+		return errors.NewInvalid("--speed must be positive")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	samples, err := readMetricSeries(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	client, conn, err := getMetricsClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	for _, sample := range samples {
+		time.Sleep(time.Until(start.Add(time.Duration(float64(sample.at) / speed))))
+		if _, err = client.Set(context.Background(), &metricsapi.SetRequest{Metric: sample.metric}); err != nil {
+			return err
+		}
+	}
+	cli.Output("Set %d metric values in %s\n", len(samples), time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMetric(t *testing.T) {
+	m, err := parseMetric("5153", "load", "float64", "0.75")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x5153), m.EntityID)
+
+	_, err = parseMetric("5153", "load", "int8", "300")
+	assert.Error(t, err)
+	_, err = parseMetric("5153", "load", "uint16", "-1")
+	assert.Error(t, err)
+	_, err = parseMetric("5153", "up", "bool", "maybe")
+	assert.Error(t, err)
+	_, err = parseMetric("5153", "load", "decimal", "1")
+	assert.Error(t, err)
+	_, err = parseMetric("xyz", "load", "string", "1")
+	assert.Error(t, err)
+	_, err = parseMetric("5153", "label", "string", "anything")
+	assert.NoError(t, err)
+}
+
+func Test_ReadMetricSeries(t *testing.T) {
+	samples, err := readMetricSeries(strings.NewReader(`entity,name,type,value,offset
+5153,load,float64,0.9,1m
+5153,load,float64,0.5
+# comment
+5154,up,bool,true,1.5
+`))
+	assert.NoError(t, err)
+	assert.Len(t, samples, 3)
+	assert.Equal(t, time.Duration(0), samples[0].at)
+	assert.Equal(t, "0.5", samples[0].metric.Value)
+	assert.Equal(t, 1500*time.Millisecond, samples[1].at)
+	assert.Equal(t, time.Minute, samples[2].at)
+
+	_, err = readMetricSeries(strings.NewReader("5153,load,int32,abc\n"))
+	assert.Error(t, err)
+	_, err = readMetricSeries(strings.NewReader("5153,load,int32\n"))
+	assert.Error(t, err)
+}
@@ -7,12 +7,17 @@ package ransim
 import (
 	"context"
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	simapi "github.com/onosproject/onos-api/go/onos/ransim/trafficsim"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 )
 
 func loadCommand() *cobra.Command {
@@ -37,6 +42,20 @@ func clearCommand() *cobra.Command {
 	return cmd
 }
 
+func exportModelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-model [<model.yaml>|-]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Export the live model as a model YAML file",
+		Long: `Export the live nodes, cells, routes, PLMN ID and map layout as a model YAML file that can be given to the load command.
+Controller and service model definitions are not available from the simulator; use --base to carry them, and
+any other sections, over from the model YAML file that was originally loaded.`,
+		RunE: runExportModelCommand,
+	}
+	cmd.Flags().String("base", "", "model YAML file whose remaining sections are retained in the export")
+	return cmd
+}
+
 func getModelClient(cmd *cobra.Command) (modelapi.ModelServiceClient, *grpc.ClientConn, error) {
 	conn, err := cli.GetConnection(cmd)
 	if err != nil {
@@ -104,3 +123,138 @@ func runClearCommand(cmd *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+func runExportModelCommand(cmd *cobra.Command, args []string) error {
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	model, err := readLiveModel(conn)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	if base, _ := cmd.Flags().GetString("base"); base != "" {
+		if data, err = mergeModelYAML(base, data); err != nil {
+			return err
+		}
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err = cli.GetOutput().Write(data)
+		return err
+	}
+	return ioutil.WriteFile(args[0], data, 0644)
+}
+
+// readLiveModel walks the nodes, cells and routes of the simulator and assembles them into a model file
+func readLiveModel(conn *grpc.ClientConn) (*modelFile, error) {
+	ctx := context.Background()
+	model := &modelFile{
+		Nodes: make(map[string]*modelNode),
+		Cells: make(map[string]*modelCell),
+	}
+
+	nodeClient := modelapi.NewNodeModelClient(conn)
+	plmnID, err := nodeClient.GetPlmnID(ctx, &modelapi.PlmnIDRequest{})
+	if err != nil {
+		return nil, err
+	}
+	model.PlmnID = types.PlmnIDToString(plmnID.PlmnID)
+
+	layout, err := simapi.NewTrafficClient(conn).GetMapLayout(ctx, &simapi.MapLayoutRequest{})
+	if err != nil {
+		return nil, err
+	}
+	model.Layout = toModelLayout(layout)
+
+	nodes, err := nodeClient.ListNodes(ctx, &modelapi.ListNodesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		r, err := nodes.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		model.Nodes[nodeKey(r.Node.GnbID)] = toModelNode(r.Node)
+	}
+
+	cells, err := modelapi.NewCellModelClient(conn).ListCells(ctx, &modelapi.ListCellsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		r, err := cells.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		model.Cells[cellKey(r.Cell.NCGI)] = toModelCell(r.Cell)
+	}
+
+	routes, err := modelapi.NewRouteModelClient(conn).ListRoutes(ctx, &modelapi.ListRoutesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		r, err := routes.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		model.DirectRoutes = append(model.DirectRoutes, toModelRoute(r.Route))
+	}
+	sort.Slice(model.DirectRoutes, func(i, j int) bool {
+		return model.DirectRoutes[i].RouteID < model.DirectRoutes[j].RouteID
+	})
+
+	ueCount, err := modelapi.NewUEModelClient(conn).GetUECount(ctx, &modelapi.GetUECountRequest{})
+	if err != nil {
+		return nil, err
+	}
+	model.UECount = ueCount.Count
+	return model, nil
+}
+
+// mergeModelYAML overlays the top-level sections of the exported model onto those of the given base model file
+func mergeModelYAML(basePath string, exported []byte) ([]byte, error) {
+	baseData, err := ioutil.ReadFile(basePath)
+	if err != nil {
+		return nil, err
+	}
+	base := yaml.MapSlice{}
+	if err = yaml.Unmarshal(baseData, &base); err != nil {
+		return nil, err
+	}
+	overlay := yaml.MapSlice{}
+	if err = yaml.Unmarshal(exported, &overlay); err != nil {
+		return nil, err
+	}
+
+	for _, item := range overlay {
+		replaced := false
+		for i := range base {
+			if base[i].Key == item.Key {
+				base[i].Value = item.Value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, item)
+		}
+	}
+	return yaml.Marshal(base)
+}
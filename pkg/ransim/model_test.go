@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_ExportModel(t *testing.T) {
+	cell := &types.Cell{
+		NCGI:      0x13842601454c001,
+		Location:  &types.Point{Lat: 52.5, Lng: 13.4},
+		Sector:    &types.Sector{Azimuth: 120, Arc: 120},
+		Neighbors: []types.NCGI{0x13842601454c002},
+		TxPowerdB: 11,
+		Pci:       42,
+		MeasurementParams: &types.MeasurementParams{
+			NcellIndividualOffsets: map[types.NCGI]int32{0x13842601454c002: 1},
+			EventA3Params:          &types.EventA3Params{A3Offset: 3},
+		},
+	}
+	model := &modelFile{
+		PlmnID: types.PlmnIDToString(types.PlmnIDFromString("138426")),
+		Cells:  map[string]*modelCell{cellKey(cell.NCGI): toModelCell(cell)},
+		Nodes: map[string]*modelNode{nodeKey(0x5153): toModelNode(&types.Node{
+			GnbID: 0x5153, CellNCGIs: []types.NCGI{cell.NCGI}, Controllers: []string{"e2t-1"}, ServiceModels: []string{"kpm"}})},
+	}
+	data, err := yaml.Marshal(model)
+	assert.NoError(t, err)
+
+	loaded := &modelFile{}
+	assert.NoError(t, yaml.Unmarshal(data, loaded))
+	assert.Equal(t, "138426", loaded.PlmnID)
+	assert.Equal(t, model.Cells, loaded.Cells)
+	assert.Equal(t, model.Nodes, loaded.Nodes)
+	assert.Equal(t, int32(3), loaded.Cells["cell-13842601454c001"].MeasurementParams.EventA3Params.A3Offset)
+
+	base := filepath.Join(t.TempDir(), "base.yaml")
+	assert.NoError(t, os.WriteFile(base, []byte("plmnID: \"314628\"\ncontrollers:\n  e2t-1:\n    id: e2t-1\n    address: onos-e2t\n    port: 36421\n"), 0644))
+	merged, err := mergeModelYAML(base, data)
+	assert.NoError(t, err)
+
+	loaded = &modelFile{}
+	assert.NoError(t, yaml.Unmarshal(merged, loaded))
+	assert.Equal(t, "138426", loaded.PlmnID)
+	assert.Equal(t, "onos-e2t", loaded.Controllers["e2t-1"].Address)
+	assert.Len(t, loaded.Cells, 1)
+}
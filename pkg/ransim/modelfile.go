@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"fmt"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// modelFile mirrors the schema of the RAN simulator model YAML accepted by the load command
+type modelFile struct {
+	PlmnID        string                        `yaml:"plmnID,omitempty"`
+	Layout        *modelLayout                  `yaml:"layout,omitempty"`
+	Nodes         map[string]*modelNode         `yaml:"nodes,omitempty"`
+	Cells         map[string]*modelCell         `yaml:"cells,omitempty"`
+	Controllers   map[string]*modelController   `yaml:"controllers,omitempty"`
+	ServiceModels map[string]*modelServiceModel `yaml:"servicemodels,omitempty"`
+	DirectRoutes  []*modelRoute                 `yaml:"directRoutes,omitempty"`
+	UECount       uint32                        `yaml:"ueCount,omitempty"`
+}
+
+type modelCoordinate struct {
+	Lat float64 `yaml:"lat"`
+	Lng float64 `yaml:"lng"`
+}
+
+type modelLayout struct {
+	Center         modelCoordinate `yaml:"center"`
+	Zoom           float32         `yaml:"zoom"`
+	Fade           bool            `yaml:"fade"`
+	ShowRoutes     bool            `yaml:"showRoutes"`
+	ShowPower      bool            `yaml:"showPower"`
+	LocationsScale float32         `yaml:"locationsScale"`
+}
+
+type modelNode struct {
+	GnbID         uint64   `yaml:"gnbid"`
+	Controllers   []string `yaml:"controllers"`
+	ServiceModels []string `yaml:"servicemodels"`
+	Cells         []uint64 `yaml:"cells"`
+	Status        string   `yaml:"status,omitempty"`
+}
+
+type modelSector struct {
+	Center  modelCoordinate `yaml:"center"`
+	Azimuth int32           `yaml:"azimuth"`
+	Arc     int32           `yaml:"arc"`
+	Tilt    int32           `yaml:"tilt,omitempty"`
+	Height  int32           `yaml:"height,omitempty"`
+}
+
+type modelEventA3Params struct {
+	A3Offset      int32 `yaml:"a3offset"`
+	ReportOnLeave bool  `yaml:"reportOnLeave"`
+}
+
+type modelMeasurementParams struct {
+	TimeToTrigger          int32              `yaml:"timeToTrigger"`
+	FrequencyOffset        int32              `yaml:"frequencyOffset"`
+	PcellIndividualOffset  int32              `yaml:"pcellIndividualOffset"`
+	NcellIndividualOffsets map[uint64]int32   `yaml:"ncellIndividualOffsets,omitempty"`
+	Hysteresis             int32              `yaml:"hysteresis"`
+	EventA3Params          modelEventA3Params `yaml:"eventA3Params"`
+}
+
+type modelCell struct {
+	NCGI              uint64                 `yaml:"ncgi"`
+	Sector            modelSector            `yaml:"sector"`
+	Color             string                 `yaml:"color"`
+	MaxUEs            uint32                 `yaml:"maxUEs"`
+	Neighbors         []uint64               `yaml:"neighbors"`
+	TxPowerDB         float64                `yaml:"txpowerdb"`
+	MeasurementParams modelMeasurementParams `yaml:"measurementParams"`
+	Pci               uint32                 `yaml:"pci"`
+	Earfcn            uint32                 `yaml:"earfcn"`
+	CellType          int32                  `yaml:"cellType"`
+}
+
+type modelController struct {
+	ID      string `yaml:"id"`
+	Address string `yaml:"address"`
+	Port    int    `yaml:"port"`
+}
+
+type modelServiceModel struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+}
+
+type modelRoute struct {
+	RouteID    uint64            `yaml:"routeID"`
+	Waypoints  []modelCoordinate `yaml:"waypoints"`
+	Color      string            `yaml:"color"`
+	SpeedAvg   uint32            `yaml:"speedAvg"`
+	SpeedStdev uint32            `yaml:"speedStdev"`
+}
+
+func nodeKey(gnbID types.GnbID) string {
+	return fmt.Sprintf("node-%x", gnbID)
+}
+
+func cellKey(ncgi types.NCGI) string {
+	return fmt.Sprintf("cell-%x", ncgi)
+}
+
+func toModelCoordinate(p *types.Point) modelCoordinate {
+	if p == nil {
+		return modelCoordinate{}
+	}
+	return modelCoordinate{Lat: p.Lat, Lng: p.Lng}
+}
+
+func fromNCGIs(ncgis []types.NCGI) []uint64 {
+	ids := make([]uint64, 0, len(ncgis))
+	for _, ncgi := range ncgis {
+		ids = append(ids, uint64(ncgi))
+	}
+	return ids
+}
+
+func toModelLayout(layout *types.MapLayout) *modelLayout {
+	return &modelLayout{
+		Center:         toModelCoordinate(layout.Center),
+		Zoom:           layout.Zoom,
+		Fade:           layout.Fade,
+		ShowRoutes:     layout.ShowRoutes,
+		ShowPower:      layout.ShowPower,
+		LocationsScale: layout.LocationsScale,
+	}
+}
+
+func toModelNode(node *types.Node) *modelNode {
+	return &modelNode{
+		GnbID:         uint64(node.GnbID),
+		Controllers:   node.Controllers,
+		ServiceModels: node.ServiceModels,
+		Cells:         fromNCGIs(node.CellNCGIs),
+		Status:        node.Status,
+	}
+}
+
+func toModelCell(cell *types.Cell) *modelCell {
+	mc := &modelCell{
+		NCGI:      uint64(cell.NCGI),
+		Color:     cell.Color,
+		MaxUEs:    cell.MaxUEs,
+		Neighbors: fromNCGIs(cell.Neighbors),
+		TxPowerDB: cell.TxPowerdB,
+		Pci:       cell.Pci,
+		Earfcn:    cell.Earfcn,
+		CellType:  int32(cell.CellType),
+	}
+	if cell.Sector != nil {
+		mc.Sector = modelSector{
+			Center:  toModelCoordinate(cell.Location),
+			Azimuth: cell.Sector.Azimuth,
+			Arc:     cell.Sector.Arc,
+			Tilt:    cell.Sector.Tilt,
+			Height:  cell.Sector.Height,
+		}
+	} else {
+		mc.Sector.Center = toModelCoordinate(cell.Location)
+	}
+	if mp := cell.MeasurementParams; mp != nil {
+		mc.MeasurementParams = modelMeasurementParams{
+			TimeToTrigger:         mp.TimeToTrigger,
+			FrequencyOffset:       mp.FrequencyOffset,
+			PcellIndividualOffset: mp.PcellIndividualOffset,
+			Hysteresis:            mp.Hysteresis,
+		}
+		if len(mp.NcellIndividualOffsets) > 0 {
+			mc.MeasurementParams.NcellIndividualOffsets = make(map[uint64]int32, len(mp.NcellIndividualOffsets))
+			for ncgi, offset := range mp.NcellIndividualOffsets {
+				mc.MeasurementParams.NcellIndividualOffsets[uint64(ncgi)] = offset
+			}
+		}
+		if mp.EventA3Params != nil {
+			mc.MeasurementParams.EventA3Params = modelEventA3Params{
+				A3Offset:      mp.EventA3Params.A3Offset,
+				ReportOnLeave: mp.EventA3Params.ReportOnLeave,
+			}
+		}
+	}
+	return mc
+}
+
+func toModelRoute(route *types.Route) *modelRoute {
+	waypoints := make([]modelCoordinate, 0, len(route.Waypoints))
+	for _, p := range route.Waypoints {
+		waypoints = append(waypoints, toModelCoordinate(p))
+	}
+	return &modelRoute{
+		RouteID:    uint64(route.RouteID),
+		Waypoints:  waypoints,
+		Color:      route.Color,
+		SpeedAvg:   route.SpeedAvg,
+		SpeedStdev: route.SpeedStdev,
+	}
+}
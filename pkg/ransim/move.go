@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func getMoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move {ues} [args]",
+		Short: "Commands for moving UEs in bulk",
+	}
+	cmd.AddCommand(moveUEsCommand())
+	return cmd
+}
+
+func moveUEsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ues --to-cell <ncgi> {--from-cell <ncgi>|--count <n>|--percent <p>|--all} [options]",
+		Args:  cobra.NoArgs,
+		Short: "Move a selection of UEs to a cell",
+		Long: `Move a selection of UEs to the given cell. UEs are selected from those served by --from-cell, or from
+all UEs, and limited by --count or --percent; moving all UEs of the simulator requires --all. UEs are handed over to the target cell directly, or with
+--within-radius relocated to random positions within the given radius around the target cell.`,
+		RunE: runMoveUEsCommand,
+	}
+	cmd.Flags().String("from-cell", "", "only move UEs served by the cell with the given NCGI (hex)")
+	cmd.Flags().String("to-cell", "", "NCGI (hex) of the target cell")
+	cmd.Flags().Int("count", 0, "number of UEs to move")
+	cmd.Flags().Float64("percent", 0, "percentage of the selected UEs to move")
+	cmd.Flags().Bool("all", false, "move all UEs when no other selection option is given")
+	cmd.Flags().Float64("within-radius", 0, "relocate UEs within this many meters of the target cell instead of handing them over")
+	cmd.Flags().Int("parallel", 16, "number of concurrent requests")
+	cmd.Flags().Int64("seed", 0, "seed used to pick UEs and positions; defaults to the current time")
+	_ = cmd.MarkFlagRequired("to-cell")
+	return cmd
+}
+
+// selectUEs picks the UEs to move from the candidates, at random when only some of them are to be moved
+func selectUEs(candidates []types.IMSI, count int, percent float64, rng *rand.Rand) []types.IMSI {
+	n := len(candidates)
+	if percent > 0 {
+		n = int(math.Round(float64(len(candidates)) * percent / 100))
+	} else if count > 0 && count < n {
+		n = count
+	}
+	if n >= len(candidates) {
+		return candidates
+	}
+	selected := append([]types.IMSI{}, candidates...)
+	rng.Shuffle(len(selected), func(i, j int) { selected[i], selected[j] = selected[j], selected[i] })
+	return selected[:n]
+}
+
+// randomLocation returns a uniformly distributed point within the radius around the cell, restricted to its sector
+func randomLocation(cell *types.Cell, radius float64, rng *rand.Rand) (*types.Point, uint32) {
+	heading := rng.Float64() * 360
+	if cell.Sector != nil && cell.Sector.Arc > 0 && cell.Sector.Arc < 360 {
+		heading = float64(cell.Sector.Azimuth) + (rng.Float64()-0.5)*float64(cell.Sector.Arc)
+	}
+	heading = math.Mod(heading+360, 360)
+	return destination(cell.Location, heading, radius*math.Sqrt(rng.Float64())), uint32(heading)
+}
+
+func runMoveUEsCommand(cmd *cobra.Command, _ []string) error {
+	toCell, _ := cmd.Flags().GetString("to-cell")
+	target, err := strconv.ParseUint(toCell, 16, 64)
+	if err != nil {
+		return errors.NewInvalid("invalid cell NCGI %s", toCell)
+	}
+	var source *types.NCGI
+	if fromCell, _ := cmd.Flags().GetString("from-cell"); fromCell != "" {
+		ncgi, err := strconv.ParseUint(fromCell, 16, 64)
+		if err != nil {
+			return errors.NewInvalid("invalid cell NCGI %s", fromCell)
+		}
+		source = (*types.NCGI)(&ncgi)
+	}
+	count, _ := cmd.Flags().GetInt("count")
+	percent, _ := cmd.Flags().GetFloat64("percent")
+	all, _ := cmd.Flags().GetBool("all")
+	if count < 0 {
+		return errors.NewInvalid("--count must not be negative")
+	}
+	if count > 0 && percent > 0 {
+		return errors.NewInvalid("--count and --percent are mutually exclusive")
+	}
+	if percent < 0 || percent > 100 {
+		return errors.NewInvalid("--percent must be within 0 and 100")
+	}
+	if all && (source != nil || count > 0 || percent > 0) {
+		return errors.NewInvalid("--all is mutually exclusive with --from-cell, --count and --percent")
+	}
+	if !all && source == nil && count == 0 && percent == 0 {
+		return errors.NewInvalid("select the UEs to move with --from-cell, --count or --percent, or use --all to move all UEs")
+	}
+	radius, _ := cmd.Flags().GetFloat64("within-radius")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	if parallel < 1 {
+		parallel = 1
+	}
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	conn, err := cli.GetConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	cell, err := modelapi.NewCellModelClient(conn).GetCell(ctx, &modelapi.GetCellRequest{NCGI: types.NCGI(target)})
+	if err != nil {
+		return err
+	}
+
+	if radius > 0 && cell.Cell.Location == nil {
+		return errors.NewInvalid("cell %x has no location", target)
+	}
+
+	client := modelapi.NewUEModelClient(conn)
+	stream, err := client.ListUEs(ctx, &modelapi.ListUEsRequest{})
+	if err != nil {
+		return err
+	}
+	candidates := make([]types.IMSI, 0)
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if r.Ue.ServingTower != types.NCGI(target) && (source == nil || r.Ue.ServingTower == *source) {
+			candidates = append(candidates, r.Ue.IMSI)
+		}
+	}
+
+	selected := selectUEs(candidates, count, percent, rng)
+	// Pick the positions up front, so that a seed reproduces them regardless of scheduling
+	locations := make([]*modelapi.MoveToLocationRequest, len(selected))
+	if radius > 0 {
+		for i, imsi := range selected {
+			location, heading := randomLocation(cell.Cell, radius, rng)
+			locations[i] = &modelapi.MoveToLocationRequest{IMSI: imsi, Location: location, Heading: heading}
+		}
+	}
+
+	var moved int32
+	var lock sync.Mutex
+	failures := make([]string, 0)
+	sem := make(chan struct{}, parallel)
+	wg := &sync.WaitGroup{}
+	for i, imsi := range selected {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, imsi types.IMSI) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var err error
+			if radius > 0 {
+				_, err = client.MoveToLocation(ctx, locations[i])
+			} else {
+				_, err = client.MoveToCell(ctx, &modelapi.MoveToCellRequest{IMSI: imsi, NCGI: types.NCGI(target)})
+			}
+			if err != nil {
+				lock.Lock()
+				failures = append(failures, fmt.Sprintf("UE %d: %v", imsi, err))
+				lock.Unlock()
+				return
+			}
+			atomic.AddInt32(&moved, 1)
+		}(i, imsi)
+	}
+	wg.Wait()
+
+	for _, failure := range failures {
+		cli.Output("%s\n", failure)
+	}
+	cli.Output("Moved %d of %d selected UEs (of %d candidates) to cell %x\n", moved, len(selected), len(candidates), target)
+	if len(failures) > 0 {
+		return fmt.Errorf("%d UE move(s) failed", len(failures))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelectUEs(t *testing.T) {
+	candidates := []types.IMSI{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	rng := rand.New(rand.NewSource(1))
+
+	assert.Equal(t, candidates, selectUEs(candidates, 0, 0, rng))
+	assert.Equal(t, candidates, selectUEs(candidates, 20, 0, rng))
+	assert.Len(t, selectUEs(candidates, 4, 0, rng), 4)
+	assert.Len(t, selectUEs(candidates, 0, 30, rng), 3)
+
+	selected := selectUEs(candidates, 0, 50, rng)
+	seen := make(map[types.IMSI]bool)
+	for _, imsi := range selected {
+		assert.False(t, seen[imsi])
+		seen[imsi] = true
+	}
+}
+
+func Test_RandomLocation(t *testing.T) {
+	cell := &types.Cell{
+		Location: &types.Point{Lat: 52.0, Lng: 13.0},
+		Sector:   &types.Sector{Azimuth: 90, Arc: 60},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		p, heading := randomLocation(cell, 500, rng)
+		assert.LessOrEqual(t, distanceMeters(cell.Location, p), 500.5)
+		assert.GreaterOrEqual(t, heading, uint32(60))
+		assert.LessOrEqual(t, heading, uint32(120))
+	}
+}
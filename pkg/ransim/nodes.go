@@ -7,6 +7,7 @@ package ransim
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
@@ -33,7 +34,7 @@ func getNodesCommand() *cobra.Command {
 		RunE:  runGetNodesCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
-	cmd.Flags().BoolP("watch", "w", false, "watch node changes")
+	addWatchFlags(cmd, "node")
 	return cmd
 }
 
@@ -136,44 +137,47 @@ func runGetNodesCommand(cmd *cobra.Command, _ []string) error {
 	}
 	defer conn.Close()
 
-	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); !noHeaders {
+	outputGetHeader(cmd, "GnbID", func() {
 		cli.Output("%-16s %-8s %-16s %-20s %s\n", "GnbID", "Status", "Service Models", "E2T Controllers", "Cell NCGIs")
-	}
+	})
 
 	if watch, _ := cmd.Flags().GetBool("watch"); watch {
 		stream, err := client.WatchNodes(context.Background(), &modelapi.WatchNodesRequest{NoReplay: false})
 		if err != nil {
 			return err
 		}
+		differ := getWatchDiffer(cmd)
 		for {
 			r, err := stream.Recv()
-			if err != nil {
-				break
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
 			}
 			node := r.Node
-			cli.Output("%-16x %-8s %-16s %-20s %s\n", node.GnbID, node.Status,
-				catStrings(node.ServiceModels), catStrings(node.Controllers), catNCGIs(node.CellNCGIs))
+			outputWatchEvent(differ, r.Type, fmt.Sprintf("%x", node.GnbID), nodeFields(node), func() { outputNodeLine(node) })
 		}
+	}
 
-	} else {
+	stream, err := client.ListNodes(context.Background(), &modelapi.ListNodesRequest{})
+	if err != nil {
+		return err
+	}
 
-		stream, err := client.ListNodes(context.Background(), &modelapi.ListNodesRequest{})
-		if err != nil {
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
 			return err
 		}
-
-		for {
-			r, err := stream.Recv()
-			if err != nil {
-				break
-			}
-			node := r.Node
-			cli.Output("%-16x %-8s %-16s %-20s %s\n", node.GnbID, node.Status,
-				catStrings(node.ServiceModels), catStrings(node.Controllers), catNCGIs(node.CellNCGIs))
-		}
+		outputNodeLine(r.Node)
 	}
+}
 
-	return nil
+func outputNodeLine(node *types.Node) {
+	cli.Output("%-16x %-8s %-16s %-20s %s\n", node.GnbID, node.Status,
+		catStrings(node.ServiceModels), catStrings(node.Controllers), catNCGIs(node.CellNCGIs))
 }
 
 func optionsToNode(cmd *cobra.Command, node *types.Node, update bool) (*types.Node, error) {
@@ -307,15 +311,24 @@ func runControlCommand(command string, cmd *cobra.Command, args []string) error
 	}
 	defer conn.Close()
 
-	request := &modelapi.AgentControlRequest{GnbID: types.GnbID(enbid), Command: command}
-	res, err := client.AgentControl(context.Background(), request)
+	node, err := controlNode(client, command, types.GnbID(enbid))
 	if err != nil {
 		return err
 	}
-	outputNode(res.Node)
+	outputNode(node)
 	return nil
 }
 
+// controlNode issues the given command, e.g. start or stop, to the agent of an E2 node
+func controlNode(client modelapi.NodeModelClient, command string, gnbID types.GnbID) (*types.Node, error) {
+	request := &modelapi.AgentControlRequest{GnbID: gnbID, Command: command}
+	res, err := client.AgentControl(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return res.Node, nil
+}
+
 func runStartNodeCommand(cmd *cobra.Command, args []string) error {
 	return runControlCommand("start", cmd, args)
 }
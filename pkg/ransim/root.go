@@ -28,7 +28,7 @@ func Init() {
 // GetCommand returns the root command for the RAN service
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "ransim {get,set,create,delete,starts,stop,load,clear} [args]",
+		Use:   "ransim {get,set,create,delete,starts,stop,load,clear,export-model,generate,lint,move,chaos,scenario} [args]",
 		Short: "ONOS RAN simulator commands",
 	}
 
@@ -45,6 +45,12 @@ func GetCommand() *cobra.Command {
 
 	cmd.AddCommand(loadCommand())
 	cmd.AddCommand(clearCommand())
+	cmd.AddCommand(exportModelCommand())
+	cmd.AddCommand(generateCommand())
+	cmd.AddCommand(lintCommand())
+	cmd.AddCommand(getMoveCommand())
+	cmd.AddCommand(chaosCommand())
+	cmd.AddCommand(getScenarioCommand())
 
 	cmd.AddCommand(loglib.GetCommand())
 	return cmd
@@ -77,7 +83,7 @@ func getDeleteCommand() *cobra.Command {
 
 func getGetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get {plmnid,layout,node(s),cell(s),ue(s),ueCount,route(s)} [args]",
+		Use:   "get {plmnid,layout,node(s),cell(s),ue(s),ueCount,route(s),geojson} [args]",
 		Short: "Commands for retrieving RAN simulator model and other information",
 	}
 
@@ -97,6 +103,8 @@ func getGetCommand() *cobra.Command {
 	cmd.AddCommand(getRouteCommand())
 	cmd.AddCommand(getRoutesCommand())
 
+	cmd.AddCommand(getGeoJSONCommand())
+
 	cmd.AddCommand(getMetricCommand())
 	cmd.AddCommand(getMetricsCommand())
 	return cmd
@@ -104,7 +112,7 @@ func getGetCommand() *cobra.Command {
 
 func getSetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "set {node,cell,ue,ueCount,metric} [args]",
+		Use:   "set {node,cell,ue,ueCount,metric(s),layout} [args]",
 		Short: "Commands for setting RAN simulator model metrics and other information",
 	}
 
@@ -112,6 +120,8 @@ func getSetCommand() *cobra.Command {
 	cmd.AddCommand(updateCellCommand())
 	cmd.AddCommand(updateUECommand())
 	cmd.AddCommand(setMetricCommand())
+	cmd.AddCommand(setMetricsCommand())
+	cmd.AddCommand(setLayoutCommand())
 	cmd.AddCommand(setUECountCommand())
 	return cmd
 }
@@ -57,6 +57,12 @@ func Test_SubCommands(t *testing.T) {
 		{commandName: "stop", expectedShort: "Stop E2 node agent"},
 		{commandName: "load", expectedShort: "Load model and/or metric data"},
 		{commandName: "clear", expectedShort: "Clear the simulated nodes, cells and metrics"},
+		{commandName: "export-model", expectedShort: "Export the live model as a model YAML file"},
+		{commandName: "generate", expectedShort: "Generate a hex-grid RAN deployment"},
+		{commandName: "lint", expectedShort: "Check a model for neighbor, PCI and coverage problems"},
+		{commandName: "move", expectedShort: "Commands for moving UEs in bulk"},
+		{commandName: "chaos", expectedShort: "Randomly stop and start E2 node agents"},
+		{commandName: "scenario", expectedShort: "Commands for running scripted simulation scenarios"},
 	}
 
 	var subCommandsFound = make(map[string]bool)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"io"
 	"strconv"
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
@@ -24,22 +25,29 @@ func getRoutesCommand() *cobra.Command {
 		RunE:  runGetRoutesCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
-	cmd.Flags().BoolP("watch", "w", false, "watch route changes")
+	addWatchFlags(cmd, "route")
 	return cmd
 }
 
 func createRouteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "route <imsi> [field options]",
-		Args:  cobra.ExactArgs(1),
-		Short: "Create an E2 node",
-		RunE:  runCreateRouteCommand,
+		Use:   "route <imsi>... [field options]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Create a UE route",
+		Long: `Create a UE route from the given waypoints, or from a GPX or GeoJSON track file.
+Given a directory of track files, the tracks are assigned in lexical order to the given IMSIs, which
+may be listed individually or as inclusive ranges such as 1000-1009; tracks are reused if there are
+more IMSIs than tracks.`,
+		RunE: runCreateRouteCommand,
 	}
 	cmd.Flags().String("color", "gray", "route color")
 	cmd.Flags().Float64("speed-avg", 80.0, "average speed in km/h")
 	cmd.Flags().Float64("speed-stddev", 0.0, "speed std. deviation in km/h")
 	cmd.Flags().Float64Slice("lat", []float64{}, "waypoint latitude")
 	cmd.Flags().Float64Slice("lng", []float64{}, "waypoint longitude")
+	cmd.Flags().String("from-file", "", "GPX or GeoJSON track file, or directory of track files")
+	cmd.Flags().Float64("min-distance", 0, "drop track points closer than this many meters to the previous point")
+	cmd.Flags().Int("max-points", 0, "thin each track to at most this many waypoints")
 	return cmd
 }
 
@@ -89,55 +97,59 @@ func runGetRoutesCommand(cmd *cobra.Command, _ []string) error {
 	}
 	defer conn.Close()
 
-	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); !noHeaders {
+	outputGetHeader(cmd, "IMSI", func() {
 		cli.Output("%-16s %-8s %-5s -%-5s %s\n", "IMSI", "Color", "µkm/h", "∂km/h", "Waypoints")
-	}
+	})
 
 	if watch, _ := cmd.Flags().GetBool("watch"); watch {
 		stream, err := client.WatchRoutes(context.Background(), &modelapi.WatchRoutesRequest{NoReplay: false})
 		if err != nil {
 			return err
 		}
+		differ := getWatchDiffer(cmd)
 		for {
 			r, err := stream.Recv()
-			if err != nil {
-				break
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
 			}
 			route := r.Route
-			cli.Output("%-16d %-8s %5.1f %5.1f %s\n", route.RouteID, route.Color,
-				float64(route.SpeedAvg)/1000, float64(route.SpeedStdev)/1000, waypointsToString(route.Waypoints))
+			outputWatchEvent(differ, r.Type, fmt.Sprintf("%d", route.RouteID), routeFields(route), func() { outputRouteLine(route) })
 		}
+	}
 
-	} else {
+	stream, err := client.ListRoutes(context.Background(), &modelapi.ListRoutesRequest{})
+	if err != nil {
+		return err
+	}
 
-		stream, err := client.ListRoutes(context.Background(), &modelapi.ListRoutesRequest{})
-		if err != nil {
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
 			return err
 		}
-
-		for {
-			r, err := stream.Recv()
-			if err != nil {
-				break
-			}
-			route := r.Route
-			cli.Output("%-16d %-8s %5.1f %5.1f %s\n", route.RouteID, route.Color,
-				float64(route.SpeedAvg)/1000, float64(route.SpeedStdev)/1000, waypointsToString(route.Waypoints))
-		}
+		outputRouteLine(r.Route)
 	}
+}
 
-	return nil
+func outputRouteLine(route *types.Route) {
+	cli.Output("%-16d %-8s %5.1f %5.1f %s\n", route.RouteID, route.Color,
+		float64(route.SpeedAvg)/1000, float64(route.SpeedStdev)/1000, waypointsToString(route.Waypoints))
 }
 
 func runCreateRouteCommand(cmd *cobra.Command, args []string) error {
-	imsi, err := strconv.ParseUint(args[0], 10, 64)
+	imsis, err := parseIMSIs(args)
 	if err != nil {
 		return err
 	}
 	color, _ := cmd.Flags().GetString("color")
 	speedAvg, _ := cmd.Flags().GetFloat64("speed-avg")
 	speedStddev, _ := cmd.Flags().GetFloat64("speed-stddev")
-	waypoints, err := waypointsFromOptions(cmd)
+
+	tracks, names, err := routeTracks(cmd, len(imsis))
 	if err != nil {
 		return err
 	}
@@ -148,20 +160,62 @@ func runCreateRouteCommand(cmd *cobra.Command, args []string) error {
 	}
 	defer conn.Close()
 
-	route := &types.Route{
-		RouteID:    types.IMSI(imsi),
-		Color:      color,
-		SpeedAvg:   uint32(speedAvg * 1000),
-		SpeedStdev: uint32(speedStddev * 1000),
-		Waypoints:  waypoints,
+	for i, imsi := range imsis {
+		track := i % len(tracks)
+		route := &types.Route{
+			RouteID:    imsi,
+			Color:      color,
+			SpeedAvg:   uint32(speedAvg * 1000),
+			SpeedStdev: uint32(speedStddev * 1000),
+			Waypoints:  tracks[track],
+		}
+
+		_, err = client.CreateRoute(context.Background(), &modelapi.CreateRouteRequest{Route: route})
+		if err != nil {
+			return err
+		}
+		if names[track] != "" {
+			cli.Output("Route %d created from %s with %d waypoints\n", imsi, names[track], len(route.Waypoints))
+		} else {
+			cli.Output("Route %d created\n", imsi)
+		}
 	}
+	return nil
+}
 
-	_, err = client.CreateRoute(context.Background(), &modelapi.CreateRouteRequest{Route: route})
+// routeTracks returns the waypoints of the routes to create, along with the names of the files they were read from
+func routeTracks(cmd *cobra.Command, imsiCount int) ([][]*types.Point, []string, error) {
+	path, _ := cmd.Flags().GetString("from-file")
+	if path == "" {
+		if imsiCount > 1 {
+			return nil, nil, errors.NewInvalid("multiple IMSIs require --from-file")
+		}
+		waypoints, err := waypointsFromOptions(cmd)
+		if err != nil {
+			return nil, nil, err
+		}
+		return [][]*types.Point{waypoints}, []string{""}, nil
+	}
+
+	if cmd.Flags().Changed("lat") || cmd.Flags().Changed("lng") {
+		return nil, nil, errors.NewInvalid("--from-file cannot be combined with --lat/--lng")
+	}
+	minDistance, _ := cmd.Flags().GetFloat64("min-distance")
+	maxPoints, _ := cmd.Flags().GetInt("max-points")
+
+	files, err := listTrackFiles(path)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	cli.Output("Route %d created\n", imsi)
-	return nil
+	tracks := make([][]*types.Point, 0, len(files))
+	for _, file := range files {
+		points, err := readTrackFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		tracks = append(tracks, downsample(points, minDistance, maxPoints))
+	}
+	return tracks, files, nil
 }
 
 func waypointsFromOptions(cmd *cobra.Command) ([]*types.Point, error) {
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	mhoapi "github.com/onosproject/onos-api/go/onos/mho"
+	"github.com/onosproject/onos-cli/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// connectionFlags are the persistent connection flags passed on to the commands of scenario steps
+var connectionFlags = []string{"service-address", "tls-cert-path", "tls-key-path", "no-tls", "auth-header"}
+
+// scenario is a sequence of timed steps, loaded from a scenario YAML file such as:
+//
+//	steps:
+//	  - at: 30s
+//	    command: set cell 13842601454c001 --tx-power-delta -6
+//	  - at: 60s
+//	    command: stop 5153
+//	  - at: 90s
+//	    command: set ueCount 200
+//	    assert:
+//	      kpimon: {measurement: RRC.ConnEstabSucc, cell: 13842601454c001, min: 10}
+type scenario struct {
+	Steps []*scenarioStep `yaml:"steps"`
+}
+
+// scenarioStep runs a ransim command line and/or checks assertions at the given offset from the scenario start
+type scenarioStep struct {
+	At          time.Duration   `yaml:"at"`
+	Description string          `yaml:"description"`
+	Command     string          `yaml:"command"`
+	Assert      *scenarioAssert `yaml:"assert"`
+}
+
+type scenarioAssert struct {
+	KPIMON *kpimonAssert `yaml:"kpimon"`
+	MHO    *mhoAssert    `yaml:"mho"`
+}
+
+// kpimonAssert checks that the latest value of a measurement lies within bounds for a cell, or for all cells
+type kpimonAssert struct {
+	Measurement string   `yaml:"measurement"`
+	Cell        string   `yaml:"cell"`
+	Min         *float64 `yaml:"min"`
+	Max         *float64 `yaml:"max"`
+}
+
+// mhoAssert checks the number of UEs that MHO reports for a cell
+type mhoAssert struct {
+	Cell   string `yaml:"cell"`
+	MinUEs *int64 `yaml:"minUEs"`
+	MaxUEs *int64 `yaml:"maxUEs"`
+}
+
+func getScenarioCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scenario {run} [args]",
+		Short: "Commands for running scripted simulation scenarios",
+	}
+	cmd.AddCommand(runScenarioCommand())
+	return cmd
+}
+
+func runScenarioCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <scenario.yaml>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Run the timed steps of a scenario",
+		Long: `Run the timed steps of a scenario YAML file. Each step is run at its 'at' offset from the start of the
+scenario and may give a 'command' with the arguments of any ransim command, e.g. 'set cell <ncgi> --tx-power-delta -6',
+'stop <enbid>' or 'set ueCount 200', and an 'assert' section checking KPIMON measurements or MHO cell state.`,
+		RunE: runRunScenarioCommand,
+	}
+	cmd.Flags().String("kpimon-address", "onos-kpimon:5150", "KPIMON address used by kpimon assertions")
+	cmd.Flags().String("mho-address", "onos-mho:5150", "MHO address used by mho assertions")
+	cmd.Flags().Bool("keep-going", false, "continue with the next steps after a failed step")
+	return cmd
+}
+
+func loadScenario(path string) (*scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &scenario{}
+	if err = yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, err
+	}
+	for i, step := range s.Steps {
+		if step.Command == "" && step.Assert == nil {
+			return nil, errors.NewInvalid("step %d has neither a command nor an assertion", i+1)
+		}
+		if step.At < 0 {
+			return nil, errors.NewInvalid("step %d has a negative offset", i+1)
+		}
+	}
+	sort.SliceStable(s.Steps, func(i, j int) bool { return s.Steps[i].At < s.Steps[j].At })
+	return s, nil
+}
+
+func runRunScenarioCommand(cmd *cobra.Command, args []string) error {
+	s, err := loadScenario(args[0])
+	if err != nil {
+		return err
+	}
+	keepGoing, _ := cmd.Flags().GetBool("keep-going")
+
+	failures := 0
+	start := time.Now()
+	for i, step := range s.Steps {
+		time.Sleep(time.Until(start.Add(step.At)))
+
+		label := step.Description
+		if label == "" {
+			label = step.Command
+		}
+		logStep(start, "step %d/%d: %s", i+1, len(s.Steps), label)
+
+		if err = runScenarioStep(cmd, step); err != nil {
+			failures++
+			logStep(start, "step %d failed: %v", i+1, err)
+			if !keepGoing {
+				break
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("scenario failed: %d of %d step(s) failed", failures, len(s.Steps))
+	}
+	logStep(start, "scenario completed: %d step(s)", len(s.Steps))
+	return nil
+}
+
+func logStep(start time.Time, msg string, args ...interface{}) {
+	now := time.Now()
+	cli.Output("%s [+%8.3fs] %s\n", now.Format("15:04:05.000"), now.Sub(start).Seconds(), fmt.Sprintf(msg, args...))
+}
+
+func runScenarioStep(cmd *cobra.Command, step *scenarioStep) error {
+	if step.Command != "" {
+		if err := runScenarioCommandLine(cmd, step.Command); err != nil {
+			return err
+		}
+	}
+	if step.Assert != nil {
+		if step.Assert.KPIMON != nil {
+			if err := assertKPIMON(cmd, step.Assert.KPIMON); err != nil {
+				return err
+			}
+		}
+		if step.Assert.MHO != nil {
+			if err := assertMHO(cmd, step.Assert.MHO); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runScenarioCommandLine executes the given ransim command line using the connection flags of the scenario command
+func runScenarioCommandLine(cmd *cobra.Command, line string) error {
+	args, err := splitCommandLine(line)
+	if err != nil {
+		return err
+	}
+	for _, name := range connectionFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			args = append(args, fmt.Sprintf("--%s=%s", name, flag.Value.String()))
+		}
+	}
+
+	root := GetCommand()
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	root.SetArgs(args)
+	return root.Execute()
+}
+
+// splitCommandLine splits a command line into arguments like a shell does, honoring single and double quotes
+// so that values may contain spaces; a backslash escapes the next character except within single quotes
+func splitCommandLine(line string) ([]string, error) {
+	args := make([]string, 0)
+	var arg strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.NewInvalid("unterminated quote or escape in command line: %s", line)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func checkBounds(what string, value float64, min *float64, max *float64) error {
+	if min != nil && value < *min {
+		return errors.NewInvalid("%s is %g; expected at least %g", what, value, *min)
+	}
+	if max != nil && value > *max {
+		return errors.NewInvalid("%s is %g; expected at most %g", what, value, *max)
+	}
+	return nil
+}
+
+func assertKPIMON(cmd *cobra.Command, a *kpimonAssert) error {
+	address, _ := cmd.Flags().GetString("kpimon-address")
+	conn, err := utils.GetServiceConnection(cmd, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := kpimonapi.NewKpimonClient(conn).ListMeasurements(context.Background(), &kpimonapi.GetRequest{})
+	if err != nil {
+		return err
+	}
+
+	checked := 0
+	for key, measItems := range resp.GetMeasurements() {
+		// Keys are formatted as <e2ID>:<nodeID>:<cellObjectID>:<cellGlobalID>
+		ids := strings.Split(key, ":")
+		if a.Cell != "" && (len(ids) != 4 || !strings.EqualFold(ids[3], a.Cell)) {
+			continue
+		}
+
+		var latest *kpimonapi.MeasurementRecord
+		for _, measItem := range measItems.MeasurementItems {
+			for _, record := range measItem.MeasurementRecords {
+				if record.MeasurementName == a.Measurement && (latest == nil || record.Timestamp > latest.Timestamp) {
+					latest = record
+				}
+			}
+		}
+		if latest == nil {
+			continue
+		}
+		value, ok := utils.MeasurementValue(latest.MeasurementValue)
+		if !ok {
+			continue
+		}
+		if err = checkBounds(fmt.Sprintf("%s of %s", a.Measurement, key), value, a.Min, a.Max); err != nil {
+			return err
+		}
+		checked++
+	}
+
+	if checked == 0 {
+		return errors.NewNotFound("no KPIMON measurement %s found", a.Measurement)
+	}
+	cli.Output("KPIMON assertion on %s passed for %d cell(s)\n", a.Measurement, checked)
+	return nil
+}
+
+func assertMHO(cmd *cobra.Command, a *mhoAssert) error {
+	address, _ := cmd.Flags().GetString("mho-address")
+	conn, err := utils.GetServiceConnection(cmd, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := mhoapi.NewMhoClient(conn).GetCells(context.Background(), &mhoapi.GetRequest{})
+	if err != nil {
+		return err
+	}
+
+	for _, cell := range resp.Cells {
+		if strings.EqualFold(cell.Cgi, a.Cell) {
+			var min, max *float64
+			if a.MinUEs != nil {
+				v := float64(*a.MinUEs)
+				min = &v
+			}
+			if a.MaxUEs != nil {
+				v := float64(*a.MaxUEs)
+				max = &v
+			}
+			if err = checkBounds(fmt.Sprintf("UE count of cell %s", cell.Cgi), float64(cell.NumUes), min, max); err != nil {
+				return err
+			}
+			cli.Output("MHO assertion on cell %s passed with %d UE(s)\n", cell.Cgi, cell.NumUes)
+			return nil
+		}
+	}
+	return errors.NewNotFound("cell %s not known to MHO", a.Cell)
+}
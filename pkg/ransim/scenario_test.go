@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+steps:
+  - at: 60s
+    command: stop 5153
+  - at: 30s
+    command: set cell 13842601454c001 --tx-power-delta -6
+  - at: 1m30s
+    description: check load
+    assert:
+      kpimon: {measurement: RRC.ConnEstabSucc, cell: 13842601454c001, min: 10}
+      mho: {cell: 13842601454c001, maxUEs: 50}
+`), 0644))
+
+	s, err := loadScenario(path)
+	assert.NoError(t, err)
+	assert.Len(t, s.Steps, 3)
+	assert.Equal(t, 30*time.Second, s.Steps[0].At)
+	assert.Equal(t, "stop 5153", s.Steps[1].Command)
+	assert.Equal(t, 90*time.Second, s.Steps[2].At)
+	assert.Equal(t, 10.0, *s.Steps[2].Assert.KPIMON.Min)
+	assert.Nil(t, s.Steps[2].Assert.KPIMON.Max)
+	assert.Equal(t, int64(50), *s.Steps[2].Assert.MHO.MaxUEs)
+
+	assert.NoError(t, os.WriteFile(path, []byte("steps:\n  - at: 10s\n"), 0644))
+	_, err = loadScenario(path)
+	assert.Error(t, err)
+}
+
+func Test_CheckBounds(t *testing.T) {
+	min, max := 1.0, 5.0
+	assert.NoError(t, checkBounds("x", 3, &min, &max))
+	assert.Error(t, checkBounds("x", 0, &min, &max))
+	assert.Error(t, checkBounds("x", 6, nil, &max))
+	assert.NoError(t, checkBounds("x", 6, nil, nil))
+}
+
+func Test_SplitCommandLine(t *testing.T) {
+	args, err := splitCommandLine(`set cell 13842601454c001 --color "dark red"  --name 'a "b" c' x\ y`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"set", "cell", "13842601454c001", "--color", "dark red", "--name", `a "b" c`, "x y"}, args)
+
+	args, err = splitCommandLine(`get cells --label 'dark red`)
+	assert.Error(t, err)
+	assert.Nil(t, args)
+
+	args, err = splitCommandLine(`get cells --label ""`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"get", "cells", "--label", ""}, args)
+}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+type gpxFile struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat float64 `xml:"lat,attr"`
+	Lng float64 `xml:"lon,attr"`
+}
+
+// geoJSONTrack holds the parts of a GeoJSON document, geometry, feature or feature collection, that can carry a track
+type geoJSONTrack struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONTrack   `json:"geometry"`
+	Features    []*geoJSONTrack `json:"features"`
+}
+
+// isTrackFile returns true if the given file name has the extension of a supported track format
+func isTrackFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpx", ".geojson", ".json":
+		return true
+	}
+	return false
+}
+
+// readTrackFile reads the waypoints of the GPX or GeoJSON track in the given file
+func readTrackFile(path string) ([]*types.Point, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*types.Point
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpx":
+		points, err = parseGPX(data)
+	case ".geojson", ".json":
+		points, err = parseGeoJSONTrack(data)
+	default:
+		return nil, errors.NewInvalid("unsupported track format %s; expected .gpx or .geojson", path)
+	}
+	if err != nil {
+		return nil, errors.NewInvalid("unable to parse %s: %v", path, err)
+	}
+	if len(points) < 2 {
+		return nil, errors.NewInvalid("track %s has fewer than 2 points", path)
+	}
+	return points, nil
+}
+
+func parseGPX(data []byte) ([]*types.Point, error) {
+	gpx := &gpxFile{}
+	if err := xml.Unmarshal(data, gpx); err != nil {
+		return nil, err
+	}
+	points := make([]*types.Point, 0)
+	for _, trk := range gpx.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				points = append(points, &types.Point{Lat: p.Lat, Lng: p.Lng})
+			}
+		}
+	}
+	for _, rte := range gpx.Routes {
+		for _, p := range rte.Points {
+			points = append(points, &types.Point{Lat: p.Lat, Lng: p.Lng})
+		}
+	}
+	return points, nil
+}
+
+func parseGeoJSONTrack(data []byte) ([]*types.Point, error) {
+	track := &geoJSONTrack{}
+	if err := json.Unmarshal(data, track); err != nil {
+		return nil, err
+	}
+	points := make([]*types.Point, 0)
+	if err := track.appendPoints(&points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// appendPoints appends the positions of all LineString and MultiLineString geometries, in document order
+func (t *geoJSONTrack) appendPoints(points *[]*types.Point) error {
+	switch t.Type {
+	case "FeatureCollection":
+		for _, f := range t.Features {
+			if err := f.appendPoints(points); err != nil {
+				return err
+			}
+		}
+	case "Feature":
+		if t.Geometry != nil {
+			return t.Geometry.appendPoints(points)
+		}
+	case "LineString":
+		var positions [][]float64
+		if err := json.Unmarshal(t.Coordinates, &positions); err != nil {
+			return err
+		}
+		return appendPositions(points, positions)
+	case "MultiLineString":
+		var lines [][][]float64
+		if err := json.Unmarshal(t.Coordinates, &lines); err != nil {
+			return err
+		}
+		for _, positions := range lines {
+			if err := appendPositions(points, positions); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func appendPositions(points *[]*types.Point, positions [][]float64) error {
+	for _, pos := range positions {
+		if len(pos) < 2 {
+			return errors.NewInvalid("invalid position %v", pos)
+		}
+		// GeoJSON positions are longitude first
+		*points = append(*points, &types.Point{Lat: pos[1], Lng: pos[0]})
+	}
+	return nil
+}
+
+// listTrackFiles returns the track file itself, or the track files of a directory in lexical order
+func listTrackFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && isTrackFile(entry.Name()) {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		return nil, errors.NewNotFound("no .gpx or .geojson tracks found in %s", path)
+	}
+	return files, nil
+}
+
+// maxIMSIs bounds the number of IMSIs that may be given, so that a mistyped range does not exhaust memory
+const maxIMSIs = 100000
+
+// parseIMSIs parses IMSIs given individually or as inclusive ranges, e.g. '1000-1009'
+func parseIMSIs(args []string) ([]types.IMSI, error) {
+	imsis := make([]types.IMSI, 0, len(args))
+	for _, arg := range args {
+		bounds := strings.SplitN(arg, "-", 2)
+		first, err := strconv.ParseUint(bounds[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.ParseUint(bounds[1], 10, 64); err != nil {
+				return nil, err
+			}
+			if last < first {
+				return nil, errors.NewInvalid("invalid IMSI range %s", arg)
+			}
+		}
+		// Compare the range size, rather than iterating up to last, so that ranges ending at the largest
+		// IMSI neither overflow nor loop forever
+		if last-first >= uint64(maxIMSIs-len(imsis)) {
+			return nil, errors.NewInvalid("too many IMSIs at %s; at most %d may be given", arg, maxIMSIs)
+		}
+		for i := uint64(0); i <= last-first; i++ {
+			imsis = append(imsis, types.IMSI(first+i))
+		}
+	}
+	return imsis, nil
+}
+
+// distanceMeters returns the great-circle distance between two points
+func distanceMeters(a *types.Point, b *types.Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// downsample drops points closer than minDistance meters to the previously kept one and then thins the
+// remainder to at most maxPoints evenly spaced points; the first and last points are always kept
+func downsample(points []*types.Point, minDistance float64, maxPoints int) []*types.Point {
+	if len(points) < 3 {
+		return points
+	}
+
+	kept := points
+	if minDistance > 0 {
+		kept = []*types.Point{points[0]}
+		for _, p := range points[1 : len(points)-1] {
+			if distanceMeters(kept[len(kept)-1], p) >= minDistance {
+				kept = append(kept, p)
+			}
+		}
+		kept = append(kept, points[len(points)-1])
+	}
+
+	if maxPoints >= 2 && len(kept) > maxPoints {
+		thinned := make([]*types.Point, 0, maxPoints)
+		step := float64(len(kept)-1) / float64(maxPoints-1)
+		for i := 0; i < maxPoints; i++ {
+			thinned = append(thinned, kept[int(math.Round(float64(i)*step))])
+		}
+		kept = thinned
+	}
+	return kept
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadTrackFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.gpx"), []byte(`<?xml version="1.0"?>
+<gpx version="1.1"><trk><trkseg>
+  <trkpt lat="52.0" lon="13.0"/><trkpt lat="52.1" lon="13.1"/><trkpt lat="52.2" lon="13.2"/>
+</trkseg></trk></gpx>`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.geojson"), []byte(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[13.0, 52.0], [13.5, 52.5]]}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.0, 52.0]}}]}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	files, err := listTrackFiles(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	points, err := readTrackFile(files[0])
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, &types.Point{Lat: 52.1, Lng: 13.1}, points[1])
+
+	points, err = readTrackFile(files[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.Point{{Lat: 52.0, Lng: 13.0}, {Lat: 52.5, Lng: 13.5}}, points)
+}
+
+func Test_Downsample(t *testing.T) {
+	points := make([]*types.Point, 0)
+	for i := 0; i < 101; i++ {
+		points = append(points, &types.Point{Lat: 52.0 + float64(i)*0.0001, Lng: 13.0})
+	}
+
+	thinned := downsample(points, 0, 11)
+	assert.Len(t, thinned, 11)
+	assert.Equal(t, points[0], thinned[0])
+	assert.Equal(t, points[100], thinned[10])
+
+	// Points are roughly 11m apart
+	spaced := downsample(points, 50, 0)
+	assert.Len(t, spaced, 21)
+	assert.Equal(t, points[100], spaced[len(spaced)-1])
+}
+
+func Test_ParseIMSIs(t *testing.T) {
+	imsis, err := parseIMSIs([]string{"1000-1002", "2000"})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.IMSI{1000, 1001, 1002, 2000}, imsis)
+
+	_, err = parseIMSIs([]string{"1002-1000"})
+	assert.Error(t, err)
+	_, err = parseIMSIs([]string{"1-18446744073709551615"})
+	assert.Error(t, err)
+	_, err = parseIMSIs([]string{"18446744073709551615-18446744073709551615"})
+	assert.NoError(t, err)
+	_, err = parseIMSIs([]string{"1-60000", "100001-160000"})
+	assert.Error(t, err)
+}
@@ -6,7 +6,11 @@ package ransim
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sort"
 	"strconv"
+	"strings"
 
 	simapi "github.com/onosproject/onos-api/go/onos/ransim/trafficsim"
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
@@ -14,6 +18,7 @@ import (
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
 	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"github.com/spf13/cobra"
 )
@@ -31,7 +36,13 @@ func getUEsCommand() *cobra.Command {
 		RunE:  runGetUEsCommand,
 	}
 	cmd.Flags().Bool("no-headers", false, "disables output headers")
-	cmd.Flags().BoolP("watch", "w", false, "watch ue changes")
+	addWatchFlags(cmd, "ue")
+	cmd.Flags().String("cell", "", "only UEs served by the cell with the given NCGI (hex)")
+	cmd.Flags().String("rrc", "", "only UEs in the given RRC state: CONNECTED, INACTIVE or IDLE")
+	cmd.Flags().Bool("admitted", false, "only admitted UEs; with --admitted=false only UEs not admitted")
+	cmd.Flags().Bool("summary", false, "summarize the UEs per serving cell")
+	cmd.Flags().String("sort", "", "sort by column: imsi, cell, crnti, rrc; or for --summary: cell, ues, strength, connected, inactive, idle")
+	cmd.Flags().Bool("reverse", false, "reverse the sort order; without --sort, sorts by IMSI, or by cell for --summary")
 	return cmd
 }
 
@@ -86,46 +97,231 @@ func getUEClient(cmd *cobra.Command) (modelapi.UEModelClient, *grpc.ClientConn,
 }
 
 func runGetUEsCommand(cmd *cobra.Command, _ []string) error {
-	client, conn, err := getUEClient(cmd)
+	filter, err := getUEFilter(cmd)
 	if err != nil {
 		return err
 	}
-	defer conn.Close()
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+	watch, _ := cmd.Flags().GetBool("watch")
+	summary, _ := cmd.Flags().GetBool("summary")
+	sortBy, _ := cmd.Flags().GetString("sort")
+	reverse, _ := cmd.Flags().GetBool("reverse")
+	if watch && (summary || sortBy != "" || reverse) {
+		return errors.NewInvalid("--summary, --sort and --reverse cannot be combined with --watch")
+	}
 
-	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); !noHeaders {
-		cli.Output("%-16s %-16s %-10s %-10s %-20s\n", "IMSI", "Serving Cell", "CRNTI", "Admitted", "RRC")
+	client, conn, err := getUEClient(cmd)
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
 
-	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+	if watch {
+		outputGetHeader(cmd, "IMSI", outputUEHeader)
 		stream, err := client.WatchUEs(context.Background(), &modelapi.WatchUEsRequest{NoReplay: false})
 		if err != nil {
 			return err
 		}
+		differ := getWatchDiffer(cmd)
 		for {
 			r, err := stream.Recv()
-			if err != nil {
-				break
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
 			}
 			ue := r.Ue
-			cli.Output("%-16d %-16x %-10d %-10t %-20s\n", ue.IMSI, ue.ServingTower, ue.CRNTI, ue.Admitted, rrcStatusName[int32(ue.RrcState)])
+			if filter.matches(ue) {
+				outputWatchEvent(differ, r.Type, fmt.Sprintf("%d", ue.IMSI), ueFields(ue), func() { outputUELine(ue) })
+			}
 		}
+	}
 
-	} else {
-		stream, err := client.ListUEs(context.Background(), &modelapi.ListUEsRequest{})
-		if err != nil {
+	stream, err := client.ListUEs(context.Background(), &modelapi.ListUEsRequest{})
+	if err != nil {
+		return err
+	}
+
+	// Without sorting or summarizing, UEs are printed as they arrive
+	buffered := summary || sortBy != "" || reverse
+	if !buffered && !noHeaders {
+		outputUEHeader()
+	}
+	ues := make([]*types.Ue, 0)
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
 			return err
 		}
+		if !filter.matches(r.Ue) {
+			continue
+		}
+		if buffered {
+			ues = append(ues, r.Ue)
+		} else {
+			outputUELine(r.Ue)
+		}
+	}
 
-		for {
-			r, err := stream.Recv()
-			if err != nil {
-				break
-			}
-			ue := r.Ue
-			cli.Output("%-16d %-16x %-10d %-10t %-20s\n", ue.IMSI, ue.ServingTower, ue.CRNTI, ue.Admitted, rrcStatusName[int32(ue.RrcState)])
+	if summary {
+		rows := summarizeUEs(ues)
+		if err = sortUESummary(rows, sortBy, reverse); err != nil {
+			return err
+		}
+		if !noHeaders {
+			cli.Output("%-16s %8s %10s %10s %10s %10s\n", "Serving Cell", "UEs", "Strength", "Connected", "Inactive", "Idle")
+		}
+		for _, row := range rows {
+			cli.Output("%-16x %8d %10.4f %10d %10d %10d\n", row.ncgi, row.ues, row.meanStrength,
+				row.rrcStates[0], row.rrcStates[1], row.rrcStates[2])
+		}
+	} else if buffered {
+		if err = sortUEs(ues, sortBy, reverse); err != nil {
+			return err
+		}
+		if !noHeaders {
+			outputUEHeader()
+		}
+		for _, ue := range ues {
+			outputUELine(ue)
 		}
 	}
+	return nil
+}
+
+func outputUEHeader() {
+	cli.Output("%-16s %-16s %-10s %-10s %-20s\n", "IMSI", "Serving Cell", "CRNTI", "Admitted", "RRC")
+}
 
+func outputUELine(ue *types.Ue) {
+	cli.Output("%-16d %-16x %-10d %-10t %-20s\n", ue.IMSI, ue.ServingTower, ue.CRNTI, ue.Admitted, rrcStatusName[int32(ue.RrcState)])
+}
+
+// ueFilter selects UEs by serving cell, RRC state and admission; unset criteria match any UE
+type ueFilter struct {
+	cell     *types.NCGI
+	rrcState *uint32
+	admitted *bool
+}
+
+func getUEFilter(cmd *cobra.Command) (*ueFilter, error) {
+	filter := &ueFilter{}
+	if cell, _ := cmd.Flags().GetString("cell"); cell != "" {
+		ncgi, err := strconv.ParseUint(cell, 16, 64)
+		if err != nil {
+			return nil, errors.NewInvalid("invalid cell NCGI %s", cell)
+		}
+		filter.cell = (*types.NCGI)(&ncgi)
+	}
+	if rrc, _ := cmd.Flags().GetString("rrc"); rrc != "" {
+		state, err := parseRRCState(rrc)
+		if err != nil {
+			return nil, err
+		}
+		filter.rrcState = &state
+	}
+	if cmd.Flags().Changed("admitted") {
+		admitted, _ := cmd.Flags().GetBool("admitted")
+		filter.admitted = &admitted
+	}
+	return filter, nil
+}
+
+// parseRRCState parses an RRC state name, with or without its RRCSTATUS_ prefix
+func parseRRCState(name string) (uint32, error) {
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "RRCSTATUS_") {
+		name = "RRCSTATUS_" + name
+	}
+	for state, stateName := range rrcStatusName {
+		if stateName == name {
+			return uint32(state), nil
+		}
+	}
+	return 0, errors.NewInvalid("invalid RRC state %s; expected CONNECTED, INACTIVE or IDLE", name)
+}
+
+func (f *ueFilter) matches(ue *types.Ue) bool {
+	return (f.cell == nil || ue.ServingTower == *f.cell) &&
+		(f.rrcState == nil || ue.RrcState == *f.rrcState) &&
+		(f.admitted == nil || ue.Admitted == *f.admitted)
+}
+
+func sortUEs(ues []*types.Ue, column string, reverse bool) error {
+	var less func(a *types.Ue, b *types.Ue) bool
+	switch column {
+	case "", "imsi":
+		less = func(a *types.Ue, b *types.Ue) bool { return a.IMSI < b.IMSI }
+	case "cell":
+		less = func(a *types.Ue, b *types.Ue) bool { return a.ServingTower < b.ServingTower }
+	case "crnti":
+		less = func(a *types.Ue, b *types.Ue) bool { return a.CRNTI < b.CRNTI }
+	case "rrc":
+		less = func(a *types.Ue, b *types.Ue) bool { return a.RrcState < b.RrcState }
+	default:
+		return errors.NewInvalid("invalid sort column %s; expected imsi, cell, crnti or rrc", column)
+	}
+	sort.SliceStable(ues, func(i, j int) bool {
+		if reverse {
+			return less(ues[j], ues[i])
+		}
+		return less(ues[i], ues[j])
+	})
+	return nil
+}
+
+// ueSummary aggregates the UEs served by one cell
+type ueSummary struct {
+	ncgi         types.NCGI
+	ues          int
+	meanStrength float64
+	// UE counts indexed by RRC state
+	rrcStates [3]int
+}
+
+func summarizeUEs(ues []*types.Ue) []*ueSummary {
+	byCell := make(map[types.NCGI]*ueSummary)
+	rows := make([]*ueSummary, 0)
+	for _, ue := range ues {
+		row, ok := byCell[ue.ServingTower]
+		if !ok {
+			row = &ueSummary{ncgi: ue.ServingTower}
+			byCell[ue.ServingTower] = row
+			rows = append(rows, row)
+		}
+		row.ues++
+		// Keep a running mean of the serving cell strength
+		row.meanStrength += (ue.ServingTowerStrength - row.meanStrength) / float64(row.ues)
+		if int(ue.RrcState) < len(row.rrcStates) {
+			row.rrcStates[ue.RrcState]++
+		}
+	}
+	return rows
+}
+
+func sortUESummary(rows []*ueSummary, column string, reverse bool) error {
+	var less func(a *ueSummary, b *ueSummary) bool
+	switch column {
+	case "", "cell":
+		less = func(a *ueSummary, b *ueSummary) bool { return a.ncgi < b.ncgi }
+	case "ues":
+		less = func(a *ueSummary, b *ueSummary) bool { return a.ues < b.ues }
+	case "strength":
+		less = func(a *ueSummary, b *ueSummary) bool { return a.meanStrength < b.meanStrength }
+	case "connected", "inactive", "idle":
+		state, _ := parseRRCState(column)
+		less = func(a *ueSummary, b *ueSummary) bool { return a.rrcStates[state] < b.rrcStates[state] }
+	default:
+		return errors.NewInvalid("invalid sort column %s; expected cell, ues, strength, connected, inactive or idle", column)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if reverse {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
 	return nil
 }
 
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUEs() []*types.Ue {
+	return []*types.Ue{
+		{IMSI: 1, ServingTower: 0xa, ServingTowerStrength: -10, RrcState: 0, Admitted: true},
+		{IMSI: 2, ServingTower: 0xa, ServingTowerStrength: -20, RrcState: 2, Admitted: true},
+		{IMSI: 3, ServingTower: 0xb, ServingTowerStrength: -30, RrcState: 0, Admitted: false},
+		{IMSI: 4, ServingTower: 0xa, ServingTowerStrength: -30, RrcState: 1, Admitted: true},
+	}
+}
+
+func Test_UEFilter(t *testing.T) {
+	cmd := getUEsCommand()
+	assert.NoError(t, cmd.Flags().Parse([]string{"--cell", "a", "--rrc", "connected", "--admitted"}))
+	filter, err := getUEFilter(cmd)
+	assert.NoError(t, err)
+
+	matched := make([]types.IMSI, 0)
+	for _, ue := range testUEs() {
+		if filter.matches(ue) {
+			matched = append(matched, ue.IMSI)
+		}
+	}
+	assert.Equal(t, []types.IMSI{1}, matched)
+
+	_, err = parseRRCState("bogus")
+	assert.Error(t, err)
+	state, err := parseRRCState("RRCSTATUS_IDLE")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), state)
+}
+
+func Test_SummarizeUEs(t *testing.T) {
+	rows := summarizeUEs(testUEs())
+	assert.NoError(t, sortUESummary(rows, "ues", true))
+	assert.Len(t, rows, 2)
+	assert.Equal(t, types.NCGI(0xa), rows[0].ncgi)
+	assert.Equal(t, 3, rows[0].ues)
+	assert.InDelta(t, -20.0, rows[0].meanStrength, 1e-9)
+	assert.Equal(t, [3]int{1, 1, 1}, rows[0].rrcStates)
+	assert.Equal(t, [3]int{1, 0, 0}, rows[1].rrcStates)
+
+	assert.NoError(t, sortUESummary(rows, "strength", false))
+	assert.Equal(t, types.NCGI(0xb), rows[0].ncgi)
+	assert.Error(t, sortUESummary(rows, "bogus", false))
+
+	ues := testUEs()
+	assert.NoError(t, sortUEs(ues, "cell", true))
+	assert.Equal(t, types.IMSI(3), ues[0].IMSI)
+}
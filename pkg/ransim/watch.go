@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"fmt"
+	"strings"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// watchField is a named, formatted field of a watched entity
+type watchField struct {
+	name  string
+	value string
+}
+
+// fieldDiffer remembers the fields of each watched entity to report the ones that changed
+type fieldDiffer struct {
+	previous map[string][]watchField
+}
+
+func newFieldDiffer() *fieldDiffer {
+	return &fieldDiffer{previous: make(map[string][]watchField)}
+}
+
+// changes returns the fields of the entity that differ from its previous event; all fields of a newly
+// seen entity are reported and deleted entities are forgotten
+func (d *fieldDiffer) changes(key string, eventType modelapi.EventType, fields []watchField) []watchField {
+	if eventType == modelapi.EventType_DELETED {
+		delete(d.previous, key)
+		return nil
+	}
+	previous, ok := d.previous[key]
+	d.previous[key] = fields
+	if !ok {
+		return fields
+	}
+
+	old := make(map[string]string, len(previous))
+	for _, f := range previous {
+		old[f.name] = f.value
+	}
+	changed := make([]watchField, 0)
+	for _, f := range fields {
+		if old[f.name] != f.value {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+func addWatchFlags(cmd *cobra.Command, entities string) {
+	cmd.Flags().BoolP("watch", "w", false, "watch "+entities+" changes")
+	cmd.Flags().Bool("diff", false, "when watching, show only the fields changed since the previous event of each entity")
+}
+
+// watchDiffMode returns true if the command watches with --diff
+func watchDiffMode(cmd *cobra.Command) bool {
+	watch, _ := cmd.Flags().GetBool("watch")
+	diff, _ := cmd.Flags().GetBool("diff")
+	return watch && diff
+}
+
+func outputDiff(eventType modelapi.EventType, key string, changes []watchField) {
+	values := make([]string, 0, len(changes))
+	for _, f := range changes {
+		values = append(values, fmt.Sprintf("%s=%s", f.name, f.value))
+	}
+	if len(values) == 0 && eventType != modelapi.EventType_DELETED {
+		values = append(values, "(no changes)")
+	}
+	cli.Output("%-8s %-17s %s\n", eventType, key, strings.Join(values, " "))
+}
+
+func nodeFields(node *types.Node) []watchField {
+	return []watchField{
+		{"status", node.Status},
+		{"serviceModels", catStrings(node.ServiceModels)},
+		{"controllers", catStrings(node.Controllers)},
+		{"cells", catNCGIs(node.CellNCGIs)},
+	}
+}
+
+func cellFields(cell *types.Cell) []watchField {
+	mp := cell.GetMeasurementParams()
+	return []watchField{
+		{"ues", fmt.Sprint(len(cell.CrntiMap))},
+		{"maxUEs", fmt.Sprint(cell.MaxUEs)},
+		{"txPowerdB", fmt.Sprintf("%.2f", cell.TxPowerdB)},
+		{"lat", fmt.Sprintf("%.3f", cell.GetLocation().GetLat())},
+		{"lng", fmt.Sprintf("%.3f", cell.GetLocation().GetLng())},
+		{"azimuth", fmt.Sprint(cell.GetSector().GetAzimuth())},
+		{"arc", fmt.Sprint(cell.GetSector().GetArc())},
+		{"a3Offset", fmt.Sprint(mp.GetEventA3Params().GetA3Offset())},
+		{"ttt", fmt.Sprint(mp.GetTimeToTrigger())},
+		{"hysteresis", fmt.Sprint(mp.GetHysteresis())},
+		{"freqOffset", fmt.Sprint(mp.GetFrequencyOffset())},
+		{"pci", fmt.Sprint(cell.Pci)},
+		{"color", cell.Color},
+		{"idle", fmt.Sprint(cell.RrcIdleCount)},
+		{"connected", fmt.Sprint(cell.RrcConnectedCount)},
+		{"neighbors", catNCGIsWithOcn(cell.Neighbors, mp.GetNcellIndividualOffsets())},
+	}
+}
+
+func ueFields(ue *types.Ue) []watchField {
+	return []watchField{
+		{"servingCell", fmt.Sprintf("%x", ue.ServingTower)},
+		{"strength", fmt.Sprintf("%.4f", ue.ServingTowerStrength)},
+		{"crnti", fmt.Sprint(ue.CRNTI)},
+		{"admitted", fmt.Sprint(ue.Admitted)},
+		{"rrc", rrcStatusName[int32(ue.RrcState)]},
+		{"lat", fmt.Sprintf("%.4f", ue.GetPosition().GetLat())},
+		{"lng", fmt.Sprintf("%.4f", ue.GetPosition().GetLng())},
+		{"heading", fmt.Sprint(ue.Rotation)},
+	}
+}
+
+func routeFields(route *types.Route) []watchField {
+	return []watchField{
+		{"color", route.Color},
+		{"speedAvg", fmt.Sprintf("%.1f", float64(route.SpeedAvg)/1000)},
+		{"speedStdev", fmt.Sprintf("%.1f", float64(route.SpeedStdev)/1000)},
+		{"nextPoint", fmt.Sprint(route.NextPoint)},
+		{"reverse", fmt.Sprint(route.Reverse)},
+		{"waypoints", waypointsToString(route.Waypoints)},
+	}
+}
+
+// outputGetHeader prints the header of a get command, prefixed with an event column when watching
+func outputGetHeader(cmd *cobra.Command, keyHeader string, header func()) {
+	if noHeaders, _ := cmd.Flags().GetBool("no-headers"); noHeaders {
+		return
+	}
+	if watchDiffMode(cmd) {
+		cli.Output("%-8s %-17s %s\n", "Event", keyHeader, "Changes")
+		return
+	}
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		cli.Output("%-8s ", "Event")
+	}
+	header()
+}
+
+// outputWatchEvent prints a watch event either as the event type followed by the usual line, or, given
+// a differ, as the fields of the entity that changed
+func outputWatchEvent(differ *fieldDiffer, eventType modelapi.EventType, key string, fields []watchField, line func()) {
+	if differ != nil {
+		outputDiff(eventType, key, differ.changes(key, eventType, fields))
+		return
+	}
+	cli.Output("%-8s ", eventType)
+	line()
+}
+
+// getWatchDiffer returns a differ if the command watches with --diff
+func getWatchDiffer(cmd *cobra.Command) *fieldDiffer {
+	if watchDiffMode(cmd) {
+		return newFieldDiffer()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ransim
+
+import (
+	"testing"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FieldDiffer(t *testing.T) {
+	differ := newFieldDiffer()
+	node := &types.Node{GnbID: 0x5153, Status: "stopped", CellNCGIs: []types.NCGI{0x1, 0x2}}
+
+	changes := differ.changes("5153", modelapi.EventType_CREATED, nodeFields(node))
+	assert.Len(t, changes, 4)
+
+	node.Status = "running"
+	changes = differ.changes("5153", modelapi.EventType_UPDATED, nodeFields(node))
+	assert.Equal(t, []watchField{{"status", "running"}}, changes)
+
+	changes = differ.changes("5153", modelapi.EventType_UPDATED, nodeFields(node))
+	assert.Empty(t, changes)
+
+	assert.Nil(t, differ.changes("5153", modelapi.EventType_DELETED, nodeFields(node)))
+	changes = differ.changes("5153", modelapi.EventType_CREATED, nodeFields(node))
+	assert.Len(t, changes, 4)
+
+	// Fields of cells without location, sector or measurement parameters are still available
+	assert.NotEmpty(t, cellFields(&types.Cell{NCGI: 0x1}))
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"crypto/tls"
+
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GetServiceConnection connects to a service other than the one of the command, using the default client
+// certificates unless TLS is disabled
+func GetServiceConnection(cmd *cobra.Command, address string) (*grpc.ClientConn, error) {
+	if noTLS, _ := cmd.Flags().GetBool("no-tls"); noTLS {
+		return grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cert, err := tls.X509KeyPair([]byte(certs.DefaultClientCrt), []byte(certs.DefaultClientKey))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: true,
+	}
+	return grpc.Dial(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
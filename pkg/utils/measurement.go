@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	prototypes "github.com/gogo/protobuf/types"
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+)
+
+// DecodeMeasurementValue unpacks the integer, real or empty value carried by a KPI measurement record
+func DecodeMeasurementValue(measValue *prototypes.Any) (interface{}, error) {
+	switch {
+	case prototypes.Is(measValue, &kpimonapi.IntegerValue{}):
+		v := kpimonapi.IntegerValue{}
+		err := prototypes.UnmarshalAny(measValue, &v)
+		return v.GetValue(), err
+
+	case prototypes.Is(measValue, &kpimonapi.RealValue{}):
+		v := kpimonapi.RealValue{}
+		err := prototypes.UnmarshalAny(measValue, &v)
+		return v.GetValue(), err
+
+	case prototypes.Is(measValue, &kpimonapi.NoValue{}):
+		v := kpimonapi.NoValue{}
+		err := prototypes.UnmarshalAny(measValue, &v)
+		return v.GetValue(), err
+	}
+	return nil, nil
+}
+
+// MeasurementValue returns the numeric value carried by a KPI measurement record, if it has one;
+// records carrying a NoValue have none
+func MeasurementValue(measValue *prototypes.Any) (float64, bool) {
+	if prototypes.Is(measValue, &kpimonapi.NoValue{}) {
+		return 0, false
+	}
+	value, err := DecodeMeasurementValue(measValue)
+	if err != nil {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
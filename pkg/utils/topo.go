@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"io"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/spf13/cobra"
+)
+
+// QueryTopoObjects returns all entities, relations and kinds of the onos-topo service at the given address
+func QueryTopoObjects(cmd *cobra.Command, address string) ([]*topoapi.Object, error) {
+	conn, err := GetServiceConnection(cmd, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	stream, err := topoapi.CreateTopoClient(conn).Query(context.Background(), &topoapi.QueryRequest{})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*topoapi.Object, 0)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return objects, nil
+		} else if err != nil {
+			return nil, err
+		}
+		objects = append(objects, resp.Object)
+	}
+}